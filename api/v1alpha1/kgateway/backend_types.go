@@ -123,6 +123,16 @@ type AwsBackend struct {
 	// +kubebuilder:validation:MaxLength=63
 	// +kubebuilder:validation:Pattern="^[a-z0-9-]+$"
 	Region string `json:"region,omitempty"`
+
+	// ServiceName is the AWS service name used to sign requests to the backend with SigV4.
+	// This is useful when a custom Lambda endpointURL expects requests signed for a different
+	// service, e.g. a proxy in front of the Lambda invoke API.
+	// Defaults to lambda if not specified.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=64
+	// +kubebuilder:validation:Pattern="^[a-z0-9-]+$"
+	ServiceName *string `json:"serviceName,omitempty"`
 }
 
 // AwsAuthType specifies the authentication method to use for the backend.
@@ -3,6 +3,7 @@ package kgateway
 import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/shared"
@@ -118,6 +119,28 @@ type KubernetesProxyConfig struct {
 	// +optional
 	Stats *StatsConfig `json:"stats,omitempty"`
 
+	// NetworkPolicyEnabled controls whether a Kubernetes NetworkPolicy is
+	// provisioned alongside the proxy pods. When set to true, the policy only
+	// allows ingress traffic to the ports of the Gateway's listeners, and to the
+	// stats port when stats are enabled; all other ingress traffic to the proxy
+	// pods is denied.
+	//
+	// When unset or set to false, a NetworkPolicy previously provisioned for the
+	// Gateway is deleted.
+	//
+	// +optional
+	NetworkPolicyEnabled *bool `json:"networkPolicyEnabled,omitempty"`
+
+	// NetworkPolicyAllowedPeers restricts the sources allowed to reach the
+	// proxy pods when NetworkPolicyEnabled is true. When set, only traffic from
+	// these peers is allowed on the listener ports; the stats port stays open to
+	// any source. When unset, traffic from any source is allowed on the listener
+	// ports.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=16
+	NetworkPolicyAllowedPeers []networkingv1.NetworkPolicyPeer `json:"networkPolicyAllowedPeers,omitempty"`
+
 	// OmitDefaultSecurityContext is used to control whether or not
 	// `securityContext` fields should be rendered for the various generated
 	// Deployments/Containers that are dynamically provisioned by the deployer.
@@ -189,6 +212,20 @@ func (in *KubernetesProxyConfig) GetStats() *StatsConfig {
 	return in.Stats
 }
 
+func (in *KubernetesProxyConfig) GetNetworkPolicyEnabled() *bool {
+	if in == nil {
+		return nil
+	}
+	return in.NetworkPolicyEnabled
+}
+
+func (in *KubernetesProxyConfig) GetNetworkPolicyAllowedPeers() []networkingv1.NetworkPolicyPeer {
+	if in == nil {
+		return nil
+	}
+	return in.NetworkPolicyAllowedPeers
+}
+
 func (in *KubernetesProxyConfig) GetOmitDefaultSecurityContext() *bool {
 	if in == nil {
 		return nil
@@ -219,6 +219,15 @@ type HTTPSettings struct {
 	// This extension sets the x-request-id header to a UUID value.
 	// +optional
 	UuidRequestIdConfig *UuidRequestIdConfig `json:"uuidRequestIdConfig,omitempty"`
+
+	// ErrorResponses replaces the body of responses generated by Envoy itself, rather than by a backend,
+	// such as a 404 when no route matches or a 429 when a request is rate limited.
+	// See here for more information: https://www.envoyproxy.io/docs/envoy/latest/configuration/http/http_conn_man/local_reply
+	// +kubebuilder:validation:MaxItems=32
+	// +listType=map
+	// +listMapKey=statusCode
+	// +optional
+	ErrorResponses []ErrorResponse `json:"errorResponses,omitempty"`
 }
 
 // AccessLog represents the top-level access log configuration.
@@ -734,7 +743,7 @@ type OpenTelemetryTracingConfig struct {
 	ResourceDetectors []ResourceDetector `json:"resourceDetectors,omitempty"`
 
 	// Specifies the sampler to be used by the OpenTelemetry tracer. This field can be left empty. In this case, the default Envoy sampling decision is used.
-	// Currently supported values are `AlwaysOn`
+	// Currently supported values are `AlwaysOn` and `TraceIDRatioBased`
 	// +optional
 	Sampler *Sampler `json:"sampler,omitempty"`
 }
@@ -756,11 +765,24 @@ type EnvironmentResourceDetectorConfig struct{}
 type Sampler struct {
 	// +optional
 	AlwaysOn *AlwaysOnConfig `json:"alwaysOnConfig,omitempty"`
+
+	// +optional
+	TraceIDRatioBased *TraceIDRatioBasedConfig `json:"traceIdRatioBasedConfig,omitempty"`
 }
 
 // AlwaysOnConfig specified the AlwaysOn samplerc
 type AlwaysOnConfig struct{}
 
+// TraceIDRatioBasedConfig specifies the TraceIdRatioBased sampler, which samples a percentage of traces based on their trace ID.
+// See https://www.envoyproxy.io/docs/envoy/latest/api-v3/extensions/tracers/opentelemetry/samplers/v3/trace_id_ratio_based_sampler.proto
+type TraceIDRatioBasedConfig struct {
+	// Target percentage of traces to sample.
+	// +required
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	SamplingPercentage int32 `json:"samplingPercentage"`
+}
+
 // GrpcStatus represents possible gRPC statuses.
 // +kubebuilder:validation:Enum=OK;CANCELED;UNKNOWN;INVALID_ARGUMENT;DEADLINE_EXCEEDED;NOT_FOUND;ALREADY_EXISTS;PERMISSION_DENIED;RESOURCE_EXHAUSTED;FAILED_PRECONDITION;ABORTED;OUT_OF_RANGE;UNIMPLEMENTED;INTERNAL;UNAVAILABLE;DATA_LOSS;UNAUTHENTICATED
 type GrpcStatus string
@@ -830,3 +852,26 @@ type UuidRequestIdConfig struct {
 	// +optional
 	UseRequestIDForTraceSampling *bool `json:"useRequestIdForTraceSampling,omitempty"`
 }
+
+// ErrorResponse replaces the body of locally generated responses with a given status code.
+type ErrorResponse struct {
+	// StatusCode is the status code of the locally generated responses whose body is replaced.
+	// +kubebuilder:validation:Minimum=100
+	// +kubebuilder:validation:Maximum=599
+	// +required
+	StatusCode int32 `json:"statusCode"`
+
+	// Body is a Go template for the response body.
+	// `{{.StatusCode}}` renders the status code of the response, and `{{.Message}}` the body Envoy would otherwise have sent,
+	// e.g. `{"code": {{.StatusCode}}, "message": "{{.Message}}"}`.
+	// A literal `%` in the body is sent as is.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=4096
+	// +required
+	Body string `json:"body"`
+
+	// ContentType is the Content-Type of the response. Defaults to `text/plain`.
+	// +kubebuilder:validation:MinLength=1
+	// +optional
+	ContentType *string `json:"contentType,omitempty"`
+}
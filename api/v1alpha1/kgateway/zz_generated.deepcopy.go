@@ -8,6 +8,7 @@ import (
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/shared"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	apisv1 "sigs.k8s.io/gateway-api/apis/v1"
@@ -308,6 +309,11 @@ func (in *AwsBackend) DeepCopyInto(out *AwsBackend) {
 		*out = new(AwsAuth)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ServiceName != nil {
+		in, out := &in.ServiceName, &out.ServiceName
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AwsBackend.
@@ -1325,6 +1331,26 @@ func (in *EnvoyHealthCheck) DeepCopy() *EnvoyHealthCheck {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ErrorResponse) DeepCopyInto(out *ErrorResponse) {
+	*out = *in
+	if in.ContentType != nil {
+		in, out := &in.ContentType, &out.ContentType
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ErrorResponse.
+func (in *ErrorResponse) DeepCopy() *ErrorResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(ErrorResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExtAuthBufferSettings) DeepCopyInto(out *ExtAuthBufferSettings) {
 	*out = *in
@@ -2107,6 +2133,13 @@ func (in *HTTPSettings) DeepCopyInto(out *HTTPSettings) {
 		*out = new(UuidRequestIdConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ErrorResponses != nil {
+		in, out := &in.ErrorResponses, &out.ErrorResponses
+		*out = make([]ErrorResponse, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPSettings.
@@ -2748,6 +2781,18 @@ func (in *KubernetesProxyConfig) DeepCopyInto(out *KubernetesProxyConfig) {
 		*out = new(StatsConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NetworkPolicyEnabled != nil {
+		in, out := &in.NetworkPolicyEnabled, &out.NetworkPolicyEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NetworkPolicyAllowedPeers != nil {
+		in, out := &in.NetworkPolicyAllowedPeers, &out.NetworkPolicyAllowedPeers
+		*out = make([]networkingv1.NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.OmitDefaultSecurityContext != nil {
 		in, out := &in.OmitDefaultSecurityContext, &out.OmitDefaultSecurityContext
 		*out = new(bool)
@@ -4045,6 +4090,11 @@ func (in *Sampler) DeepCopyInto(out *Sampler) {
 		*out = new(AlwaysOnConfig)
 		**out = **in
 	}
+	if in.TraceIDRatioBased != nil {
+		in, out := &in.TraceIDRatioBased, &out.TraceIDRatioBased
+		*out = new(TraceIDRatioBasedConfig)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Sampler.
@@ -4574,6 +4624,21 @@ func (in *TokenBucket) DeepCopy() *TokenBucket {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TraceIDRatioBasedConfig) DeepCopyInto(out *TraceIDRatioBasedConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TraceIDRatioBasedConfig.
+func (in *TraceIDRatioBasedConfig) DeepCopy() *TraceIDRatioBasedConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TraceIDRatioBasedConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Tracing) DeepCopyInto(out *Tracing) {
 	*out = *in
@@ -6,21 +6,41 @@ import (
 
 	"github.com/spf13/cobra"
 
+	apisettings "github.com/kgateway-dev/kgateway/v2/api/settings"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/setup"
 	"github.com/kgateway-dev/kgateway/v2/pkg/version"
 )
 
 func main() {
-	var kgatewayVersion bool
+	var (
+		kgatewayVersion bool
+		leaderElect     bool
+	)
 	cmd := &cobra.Command{
 		Use:   "kgateway",
 		Short: "Runs the kgateway controller",
+		Long: `Runs the kgateway controller.
+
+The controller is configured through KGW_ prefixed environment variables.
+
+Leader election is enabled by default, so only one replica of the controller deploys proxies and writes statuses at a time,
+while every replica serves xDS. The leader holds a coordination.k8s.io Lease in the namespace of the pod
+(POD_NAMESPACE, or kgateway-system if unset), named "kgateway", or "kgateway-envoy" / "kgateway-agentgateway"
+when only the envoy or agentgateway controller is enabled.
+Set --leader-elect=false, or KGW_DISABLE_LEADER_ELECTION=true, to disable it. The flag takes precedence when set.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if kgatewayVersion {
 				fmt.Println(version.String())
 				return nil
 			}
-			s, err := setup.New()
+			settings, err := apisettings.BuildSettings()
+			if err != nil {
+				return fmt.Errorf("error loading settings from env: %w", err)
+			}
+			if cmd.Flags().Changed("leader-elect") {
+				settings.DisableLeaderElection = !leaderElect
+			}
+			s, err := setup.New(setup.WithGlobalSettings(settings))
 			if err != nil {
 				return fmt.Errorf("error setting up kgateway: %w", err)
 			}
@@ -32,6 +52,7 @@ func main() {
 		},
 	}
 	cmd.Flags().BoolVarP(&kgatewayVersion, "version", "v", false, "Print the version of kgateway")
+	cmd.Flags().BoolVar(&leaderElect, "leader-elect", true, "Enable leader election, so that only one replica deploys proxies and writes statuses. Overrides KGW_DISABLE_LEADER_ELECTION when set")
 
 	if err := cmd.Execute(); err != nil {
 		log.Fatal(err)
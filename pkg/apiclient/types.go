@@ -5,6 +5,7 @@ import (
 
 	"istio.io/istio/pkg/config/schema/kubeclient"
 	"istio.io/istio/pkg/kube/kubetypes"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/watch"
@@ -16,6 +17,21 @@ import (
 
 // RegisterTypes registers all the types used by our API Client
 func RegisterTypes() {
+	// kubernetes types which are not known to istio
+	kubeclient.Register(
+		wellknown.NetworkPolicyGVR,
+		wellknown.NetworkPolicyGVK,
+		func(c kubeclient.ClientGetter, namespace string, o metav1.ListOptions) (runtime.Object, error) {
+			return c.Kube().NetworkingV1().NetworkPolicies(namespace).List(context.Background(), o)
+		},
+		func(c kubeclient.ClientGetter, namespace string, o metav1.ListOptions) (watch.Interface, error) {
+			return c.Kube().NetworkingV1().NetworkPolicies(namespace).Watch(context.Background(), o)
+		},
+		func(c kubeclient.ClientGetter, namespace string) kubetypes.WriteAPI[*networkingv1.NetworkPolicy] {
+			return c.Kube().NetworkingV1().NetworkPolicies(namespace)
+		},
+	)
+
 	// kgateway types
 	kubeclient.Register(
 		wellknown.GatewayParametersGVR,
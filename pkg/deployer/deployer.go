@@ -6,7 +6,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"slices"
 
 	"helm.sh/helm/v3/pkg/action"
 	"helm.sh/helm/v3/pkg/chart"
@@ -29,6 +28,7 @@ import (
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
 	"github.com/kgateway-dev/kgateway/v2/pkg/logging"
 	"github.com/kgateway-dev/kgateway/v2/pkg/utils/kubeutils"
+	"github.com/kgateway-dev/kgateway/v2/pkg/utils/sliceutils"
 )
 
 var logger = logging.New("deployer")
@@ -264,6 +264,56 @@ func (d *Deployer) GetObjsToDeploy(ctx context.Context, obj client.Object) ([]cl
 	return objs, nil
 }
 
+// GetObjsToPrune returns the objects which are no longer provisioned for obj, and must be deleted if they exist.
+// It returns nil unless the HelmValuesGenerator implements ObjectPruner.
+func (d *Deployer) GetObjsToPrune(ctx context.Context, obj client.Object) ([]client.Object, error) {
+	pruner, ok := d.helmValues.(ObjectPruner)
+	if !ok {
+		return nil, nil
+	}
+	objs, err := pruner.GetObjsToPrune(ctx, obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get objects to prune for %s.%s: %w", obj.GetNamespace(), obj.GetName(), err)
+	}
+	return objs, nil
+}
+
+// PruneObjs deletes the provided objects if they exist and are controlled by owner, and reports whether any
+// object was deleted. Objects which are not controlled by owner, e.g. ones created by users with the same name,
+// are left untouched.
+func (d *Deployer) PruneObjs(ctx context.Context, owner client.Object, objs []client.Object) (bool, error) {
+	pruned := false
+	for _, obj := range objs {
+		gvr, err := d.gvkToGVR(obj.GetObjectKind().GroupVersionKind())
+		if err != nil {
+			return pruned, fmt.Errorf("error getting GVR for object %s: %w", kubeutils.NamespacedNameFrom(obj), err)
+		}
+
+		c := d.client.Dynamic().Resource(gvr).Namespace(obj.GetNamespace())
+		existing, err := c.Get(ctx, obj.GetName(), metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return pruned, fmt.Errorf("error getting object %s to prune: %w", kubeutils.NamespacedNameFrom(obj), err)
+		}
+		if ref := metav1.GetControllerOf(existing); ref == nil || ref.UID != owner.GetUID() {
+			continue
+		}
+
+		logger.Debug("pruning object", "kind", obj.GetObjectKind(), "namespace", obj.GetNamespace(), "name", obj.GetName())
+		err = c.Delete(ctx, obj.GetName(), metav1.DeleteOptions{})
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return pruned, fmt.Errorf("failed to prune object %s %s: %w", gvr.String(), kubeutils.NamespacedNameFrom(obj), err)
+		}
+		pruned = true
+	}
+	return pruned, nil
+}
+
 // Deprecated: use SetNamespaceAndOwnerWithGVK
 // Using this without specifying the GVK breaks with client-go clients which do not set the
 // GVK in the TypeMeta after the initial List()
@@ -320,10 +370,13 @@ func (d *Deployer) getControllerNameForGatewayClass(ctx context.Context, gateway
 }
 
 func (d *Deployer) DeployObjs(ctx context.Context, objs []client.Object) error {
-	return d.DeployObjsWithSource(ctx, objs, nil)
+	_, err := d.DeployObjsWithSource(ctx, objs, nil)
+	return err
 }
 
-func (d *Deployer) DeployObjsWithSource(ctx context.Context, objs []client.Object, sourceObj client.Object) error {
+// DeployObjsWithSource applies the provided objects on behalf of sourceObj, and reports whether any object
+// was created or updated. Objects which are unchanged from their existing version are not applied.
+func (d *Deployer) DeployObjsWithSource(ctx context.Context, objs []client.Object, sourceObj client.Object) (bool, error) {
 	// Determine the correct controller name based on the source object
 	controllerName := d.controllerName
 	if sourceObj != nil {
@@ -338,14 +391,15 @@ func (d *Deployer) DeployObjsWithSource(ctx context.Context, objs []client.Objec
 		// For other object types, use the default controllerName
 	}
 
+	changed := false
 	for _, obj := range objs {
 		u, err := kubeutils.ToUnstructured(obj)
 		if err != nil {
-			return fmt.Errorf("error converting object %s to unstructured: %w", kubeutils.NamespacedNameFrom(obj), err)
+			return changed, fmt.Errorf("error converting object %s to unstructured: %w", kubeutils.NamespacedNameFrom(obj), err)
 		}
 		gvr, err := d.gvkToGVR(obj.GetObjectKind().GroupVersionKind())
 		if err != nil {
-			return fmt.Errorf("error getting GVR for object %s: %w", kubeutils.NamespacedNameFrom(obj), err)
+			return changed, fmt.Errorf("error getting GVR for object %s: %w", kubeutils.NamespacedNameFrom(obj), err)
 		}
 
 		// Get the existing object from the cache to check if it needs to be updated
@@ -395,13 +449,14 @@ func (d *Deployer) DeployObjsWithSource(ctx context.Context, objs []client.Objec
 		logger.Debug("deploying object", "kind", obj.GetObjectKind(), "namespace", obj.GetNamespace(), "name", obj.GetName())
 		js, err := json.Marshal(u.Object)
 		if err != nil {
-			return err
+			return changed, err
 		}
 		if err := d.patcher(d.client, controllerName, gvr, u.GetName(), u.GetNamespace(), js); err != nil {
-			return fmt.Errorf("failed to apply object %s %s/%s: %w", u.GetObjectKind().GroupVersionKind().String(), u.GetNamespace(), u.GetName(), err)
+			return changed, fmt.Errorf("failed to apply object %s %s/%s: %w", u.GetObjectKind().GroupVersionKind().String(), u.GetNamespace(), u.GetName(), err)
 		}
+		changed = true
 	}
-	return nil
+	return changed, nil
 }
 
 func (d *Deployer) gvkToGVR(gvk schema.GroupVersionKind) (schema.GroupVersionResource, error) {
@@ -446,10 +501,12 @@ func (d *Deployer) GetGvksToWatch(ctx context.Context, vals map[string]any) ([]s
 	}
 	var ret []schema.GroupVersionKind
 	for _, obj := range objs {
-		gvk := obj.GetObjectKind().GroupVersionKind()
-		if !slices.Contains(ret, gvk) {
-			ret = append(ret, gvk)
-		}
+		ret = sliceutils.AppendIfMissingG(ret, obj.GetObjectKind().GroupVersionKind())
+	}
+	// The NetworkPolicy is not rendered by the chart: it is added by the post processor when enabled,
+	// and pruned otherwise, so it must be watched for out-of-band changes and deletions too.
+	if _, ok := d.helmValues.(ObjectPruner); ok {
+		ret = sliceutils.AppendIfMissingG(ret, wellknown.NetworkPolicyGVK)
 	}
 
 	logger.Debug("watching GVKs", "gvks", ret)
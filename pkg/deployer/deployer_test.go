@@ -16,9 +16,11 @@ import (
 	"istio.io/istio/pkg/config/schema/gvk"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -2338,6 +2340,21 @@ var _ = Describe("Deployer", func() {
 			}, &expectedOutput{
 				getObjsErr: deployer.GatewayParametersError,
 			}),
+			Entry("invalid GatewayParameters", &input{
+				dInputs: defaultDeployerInputs(),
+				gw:      defaultGateway(),
+				defaultGwp: func() *kgateway.GatewayParameters {
+					params := fullyDefinedGatewayParams()
+					params.Spec.Kube.Deployment.Strategy = &appsv1.DeploymentStrategy{
+						Type:          appsv1.RecreateDeploymentStrategyType,
+						RollingUpdate: &appsv1.RollingUpdateDeployment{},
+					}
+					return params
+				}(),
+			}, &expectedOutput{
+				getObjsErr: fmt.Errorf("%w %s/%s: spec.kube.deployment.strategy.rollingUpdate: Forbidden",
+					deployerinternal.ErrInvalidParameters, defaultNamespace, wellknown.DefaultGatewayParametersName),
+			}),
 			Entry("No GatewayParameters override but default is self-managed; should not deploy gateway", &input{
 				dInputs:    defaultDeployerInputs(),
 				gw:         defaultGateway(),
@@ -2735,8 +2752,9 @@ var _ = Describe("DeployObjs", func() {
 		})
 		fc.RunAndWait(context.Background().Done())
 
-		err := d.DeployObjs(ctx, []client.Object{cm})
+		changed, err := d.DeployObjsWithSource(ctx, []client.Object{cm}, nil)
 		Expect(err).ToNot(HaveOccurred())
+		Expect(changed).To(BeFalse())
 	})
 
 	It("skips patch when only change is object status", func() {
@@ -2777,9 +2795,10 @@ var _ = Describe("DeployObjs", func() {
 		})
 		fc.RunAndWait(context.Background().Done())
 
-		err := d.DeployObjs(ctx, []client.Object{cm})
+		changed, err := d.DeployObjsWithSource(ctx, []client.Object{cm}, nil)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(patched).To(BeTrue())
+		Expect(changed).To(BeTrue())
 	})
 
 	It("patches if object does not exist (IsNotFound error)", func() {
@@ -2825,7 +2844,7 @@ var _ = Describe("DeployObjs", func() {
 		})
 		fc.RunAndWait(context.Background().Done())
 
-		err := d.DeployObjsWithSource(ctx, []client.Object{cm}, gw)
+		_, err := d.DeployObjsWithSource(ctx, []client.Object{cm}, gw)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(usedFieldManager).To(Equal(wellknown.DefaultAgwControllerName))
 	})
@@ -2850,8 +2869,99 @@ var _ = Describe("DeployObjs", func() {
 		})
 		fc.RunAndWait(context.Background().Done())
 
-		err := d.DeployObjsWithSource(ctx, []client.Object{cm}, gw)
+		_, err := d.DeployObjsWithSource(ctx, []client.Object{cm}, gw)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(usedFieldManager).To(Equal(wellknown.DefaultAgwControllerName))
 	})
 })
+
+var _ = Describe("PruneObjs", func() {
+	var (
+		ns  = "test-ns"
+		ctx = context.Background()
+		gw  = &gwv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-gw", Namespace: ns, UID: "12345"},
+		}
+	)
+
+	configMap := func(name string, ownerUID string) *corev1.ConfigMap {
+		cm := &corev1.ConfigMap{
+			TypeMeta:   metav1.TypeMeta{Kind: gvk.ConfigMap.Kind, APIVersion: gvk.ConfigMap.GroupVersion()},
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
+		}
+		if ownerUID != "" {
+			cm.OwnerReferences = []metav1.OwnerReference{{
+				APIVersion: wellknown.GatewayGVK.GroupVersion().String(),
+				Kind:       wellknown.GatewayGVK.Kind,
+				Name:       gw.Name,
+				UID:        k8stypes.UID(ownerUID),
+				Controller: ptr.To(true),
+			}}
+		}
+		return cm
+	}
+
+	It("deletes objects controlled by the owner, and leaves others untouched", func() {
+		owned := configMap("owned", string(gw.UID))
+		otherOwner := configMap("other-owner", "67890")
+		unowned := configMap("unowned", "")
+		fc := fake.NewClient(GinkgoT(), owned.DeepCopy(), otherOwner.DeepCopy(), unowned.DeepCopy())
+		d, err := deployerinternal.NewGatewayDeployer(
+			wellknown.DefaultGatewayControllerName,
+			wellknown.DefaultAgwControllerName,
+			wellknown.DefaultAgwClassName,
+			scheme,
+			fc,
+			nil,
+		)
+		Expect(err).ToNot(HaveOccurred())
+		fc.RunAndWait(context.Background().Done())
+
+		pruned, err := d.PruneObjs(ctx, gw, []client.Object{owned, otherOwner, unowned, configMap("missing", "")})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pruned).To(BeTrue())
+
+		cms := fc.Dynamic().Resource(corev1.SchemeGroupVersion.WithResource("configmaps")).Namespace(ns)
+		_, err = cms.Get(ctx, owned.Name, metav1.GetOptions{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue(), "expected the owned object to be deleted, got: %v", err)
+		_, err = cms.Get(ctx, otherOwner.Name, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = cms.Get(ctx, unowned.Name, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		// nothing left to delete
+		pruned, err = d.PruneObjs(ctx, gw, []client.Object{owned, otherOwner, unowned})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pruned).To(BeFalse())
+	})
+})
+
+var _ = Describe("GetGvksToWatch", func() {
+	It("includes the NetworkPolicy, which is provisioned outside of the chart", func() {
+		fc := fake.NewClient(GinkgoT())
+		gwp := deployerinternal.NewGatewayParameters(fc, &deployer.Inputs{
+			CommonCollections: deployertest.NewCommonCols(GinkgoT()),
+			ImageInfo:         &deployer.ImageInfo{},
+		})
+		d, err := deployerinternal.NewGatewayDeployer(
+			wellknown.DefaultGatewayControllerName,
+			wellknown.DefaultAgwControllerName,
+			wellknown.DefaultAgwClassName,
+			scheme,
+			fc,
+			gwp,
+		)
+		Expect(err).ToNot(HaveOccurred())
+
+		gvks, err := d.GetGvksToWatch(context.Background(), map[string]any{
+			"gateway": map[string]any{
+				"istio":          map[string]any{"enabled": false},
+				"image":          map[string]any{"repository": "envoy", "tag": "v1"},
+				"sdsContainer":   map[string]any{"image": map[string]any{"repository": "sds", "tag": "v1"}},
+				"istioContainer": map[string]any{"image": map[string]any{"repository": "istio", "tag": "v1"}},
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(gvks).To(ContainElements(wellknown.DeploymentGVK, wellknown.ServiceGVK, wellknown.NetworkPolicyGVK))
+	})
+})
@@ -29,3 +29,13 @@ type ObjectPostProcessor interface {
 	// (e.g., PodDisruptionBudget, HorizontalPodAutoscaler).
 	PostProcessObjects(ctx context.Context, obj client.Object, rendered []client.Object) ([]client.Object, error)
 }
+
+// ObjectPruner is an optional interface that can be implemented by HelmValuesGenerator
+// to delete objects which are only provisioned conditionally. Deploying the rendered objects
+// never deletes the objects which are no longer rendered, so once the condition no longer holds,
+// the objects returned here are deleted instead.
+type ObjectPruner interface {
+	// GetObjsToPrune returns the objects which are not provisioned for the given object, and
+	// must be deleted if they exist. Only their type, name and namespace are used.
+	GetObjsToPrune(ctx context.Context, obj client.Object) ([]client.Object, error)
+}
@@ -39,6 +39,8 @@ func DeepMergeGatewayParameters(dst, src *kgateway.GatewayParameters) {
 	dstKube.ServiceAccount = deepMergeServiceAccount(dstKube.GetServiceAccount(), srcKube.GetServiceAccount())
 	dstKube.Istio = deepMergeIstioIntegration(dstKube.GetIstio(), srcKube.GetIstio())
 	dstKube.Stats = deepMergeStatsConfig(dstKube.GetStats(), srcKube.GetStats())
+	dstKube.NetworkPolicyEnabled = MergePointers(dstKube.GetNetworkPolicyEnabled(), srcKube.GetNetworkPolicyEnabled())
+	dstKube.NetworkPolicyAllowedPeers = OverrideSlices(dstKube.GetNetworkPolicyAllowedPeers(), srcKube.GetNetworkPolicyAllowedPeers())
 	dstKube.OmitDefaultSecurityContext = MergePointers(dstKube.GetOmitDefaultSecurityContext(), srcKube.GetOmitDefaultSecurityContext())
 }
 
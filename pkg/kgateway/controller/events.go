@@ -0,0 +1,48 @@
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// GatewayEventReason is the reason of a Kubernetes Event emitted for a Gateway by the gateway controller.
+// The reasons describe what the controller does rather than how: proxy resources are applied with server-side
+// apply instead of a Helm upgrade, so there are no HelmUpgradeStarted/Succeeded/Failed reasons, and the controller
+// does not rotate certificates, so there is no CertificateRotated reason.
+type GatewayEventReason string
+
+const (
+	// GatewayEventReasonDeployed is emitted when the proxy objects for a Gateway were applied successfully
+	GatewayEventReasonDeployed GatewayEventReason = "Deployed"
+	// GatewayEventReasonDeployFailed is emitted when the proxy objects for a Gateway could not be applied
+	GatewayEventReasonDeployFailed GatewayEventReason = "DeployFailed"
+	// GatewayEventReasonInvalidParameters is emitted when the proxy objects for a Gateway could not be rendered
+	// from its GatewayParameters
+	GatewayEventReasonInvalidParameters GatewayEventReason = "InvalidParameters"
+)
+
+// GatewayEventRecorder records Kubernetes Events on Gateways with a fixed set of reasons
+type GatewayEventRecorder struct {
+	recorder record.EventRecorder
+}
+
+// NewGatewayEventRecorder returns a GatewayEventRecorder which emits events through recorder
+func NewGatewayEventRecorder(recorder record.EventRecorder) *GatewayEventRecorder {
+	return &GatewayEventRecorder{recorder: recorder}
+}
+
+// Deployed records that the proxy objects for gw were applied successfully
+func (r *GatewayEventRecorder) Deployed(gw *gwv1.Gateway) {
+	r.recorder.Event(gw, corev1.EventTypeNormal, string(GatewayEventReasonDeployed), "Deployed proxy resources")
+}
+
+// DeployFailed records that the proxy objects for gw could not be applied
+func (r *GatewayEventRecorder) DeployFailed(gw *gwv1.Gateway, err error) {
+	r.recorder.Eventf(gw, corev1.EventTypeWarning, string(GatewayEventReasonDeployFailed), "Failed to deploy proxy resources: %v", err)
+}
+
+// InvalidParameters records that the proxy objects for gw could not be rendered from its parameters
+func (r *GatewayEventRecorder) InvalidParameters(gw *gwv1.Gateway, err error) {
+	r.recorder.Eventf(gw, corev1.EventTypeWarning, string(GatewayEventReasonInvalidParameters), "Failed to render proxy resources: %v", err)
+}
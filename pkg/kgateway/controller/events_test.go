@@ -0,0 +1,131 @@
+package controller
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
+	"github.com/kgateway-dev/kgateway/v2/pkg/schemes"
+)
+
+func TestGatewayEventRecorder(t *testing.T) {
+	t.Parallel()
+
+	gw := &gwv1.Gateway{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: gwv1.GroupVersion.String(),
+			Kind:       "Gateway",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "gw",
+			Namespace: "default",
+		},
+	}
+	const involvedObject = "involvedObject{kind=Gateway,apiVersion=gateway.networking.k8s.io/v1}"
+
+	tests := []struct {
+		name     string
+		record   func(r *GatewayEventRecorder)
+		expected string
+	}{
+		{
+			name:     "deployed",
+			record:   func(r *GatewayEventRecorder) { r.Deployed(gw) },
+			expected: "Normal Deployed Deployed proxy resources " + involvedObject,
+		},
+		{
+			name:     "deploy failed",
+			record:   func(r *GatewayEventRecorder) { r.DeployFailed(gw, errors.New("apply failed")) },
+			expected: "Warning DeployFailed Failed to deploy proxy resources: apply failed " + involvedObject,
+		},
+		{
+			name:     "invalid parameters",
+			record:   func(r *GatewayEventRecorder) { r.InvalidParameters(gw, errors.New("bad replicas")) },
+			expected: "Warning InvalidParameters Failed to render proxy resources: bad replicas " + involvedObject,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fake := record.NewFakeRecorder(1)
+			fake.IncludeObject = true
+			tt.record(NewGatewayEventRecorder(fake))
+
+			require.Len(t, fake.Events, 1)
+			require.Equal(t, tt.expected, <-fake.Events)
+		})
+	}
+}
+
+func TestGatewayReconcilerEvents(t *testing.T) {
+	tests := []struct {
+		name        string
+		patchErr    error
+		wantType    string
+		wantReason  GatewayEventReason
+		wantMessage string
+	}{
+		{
+			name:        "successful deploy",
+			wantType:    corev1.EventTypeNormal,
+			wantReason:  GatewayEventReasonDeployed,
+			wantMessage: "Deployed proxy resources",
+		},
+		{
+			name:        "failed deploy",
+			patchErr:    errors.New("apply failed"),
+			wantType:    corev1.EventTypeWarning,
+			wantReason:  GatewayEventReasonDeployFailed,
+			wantMessage: "Failed to deploy proxy resources: failed to apply object /v1, Kind=ServiceAccount default/gw: apply failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// use a real recorder, so that the involved object is resolved from the scheme as it is in a running controller
+			events := make(chan *corev1.Event, 10)
+			broadcaster := record.NewBroadcaster()
+			broadcaster.StartEventWatcher(func(e *corev1.Event) { events <- e })
+			defer broadcaster.Shutdown()
+			recorder := broadcaster.NewRecorder(schemes.GatewayScheme(), corev1.EventSource{Component: wellknown.DefaultGatewayControllerName})
+
+			gw := testGateway()
+			r, _ := newTestGatewayReconciler(t, recorder, tt.patchErr, testGatewayClass(), testGatewayParameters(nil), gw, testProxyService(gw))
+
+			err := r.Reconcile(types.NamespacedName{Name: gw.Name, Namespace: gw.Namespace})
+			if tt.patchErr != nil {
+				require.ErrorIs(t, err, tt.patchErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			var event *corev1.Event
+			select {
+			case event = <-events:
+			case <-time.After(5 * time.Second):
+				t.Fatal("timed out waiting for an event to be recorded")
+			}
+			require.Equal(t, tt.wantType, event.Type)
+			require.Equal(t, string(tt.wantReason), event.Reason)
+			require.Equal(t, tt.wantMessage, event.Message)
+			require.Equal(t, corev1.ObjectReference{
+				Kind:       wellknown.GatewayKind,
+				APIVersion: gwv1.GroupVersion.String(),
+				Name:       gw.Name,
+				Namespace:  gw.Namespace,
+				UID:        gw.UID,
+			}, event.InvolvedObject)
+			require.Empty(t, events, "expected a single event")
+		})
+	}
+}
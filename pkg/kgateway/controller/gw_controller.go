@@ -17,6 +17,7 @@ import (
 	"istio.io/istio/pkg/kube/krt"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -68,9 +69,13 @@ type gatewayReconciler struct {
 	deploymentClient kclient.Client[*appsv1.Deployment]
 	svcAccountClient kclient.Client[*corev1.ServiceAccount]
 	configMapClient  kclient.Client[*corev1.ConfigMap]
+	// networkPolicyClient watches the conditionally provisioned NetworkPolicies of the proxies
+	networkPolicyClient kclient.Client[*networkingv1.NetworkPolicy]
 
 	controllerExtension pluginsdk.GatewayControllerExtension
 
+	events *GatewayEventRecorder
+
 	queue controllers.Queue
 }
 
@@ -90,6 +95,7 @@ func NewGatewayReconciler(
 		enableEnvoy:         cfg.CommonCollections.Settings.EnableEnvoy,
 		enableAgw:           cfg.CommonCollections.Settings.EnableAgentgateway,
 		controllerExtension: controllerExtension,
+		events:              NewGatewayEventRecorder(cfg.Mgr.GetEventRecorderFor(cfg.ControllerName)),
 
 		gwClient:         kclient.NewFilteredDelayed[*gwv1.Gateway](cfg.Client, gvr.KubernetesGateway, filter),
 		gwClassClient:    kclient.NewFilteredDelayed[*gwv1.GatewayClass](cfg.Client, gvr.GatewayClass, filter),
@@ -98,6 +104,8 @@ func NewGatewayReconciler(
 		deploymentClient: kclient.NewFiltered[*appsv1.Deployment](cfg.Client, filter),
 		svcAccountClient: kclient.NewFiltered[*corev1.ServiceAccount](cfg.Client, filter),
 		configMapClient:  kclient.NewFiltered[*corev1.ConfigMap](cfg.Client, filter),
+
+		networkPolicyClient: kclient.NewFiltered[*networkingv1.NetworkPolicy](cfg.Client, filter),
 	}
 
 	// Reuse the parameter clients from the deployer to avoid duplicate watches
@@ -262,6 +270,7 @@ func NewGatewayReconciler(
 	r.svcAccountClient.AddEventHandler(parentHandler)
 	r.svcClient.AddEventHandler(parentHandler)
 	r.configMapClient.AddEventHandler(parentHandler)
+	r.networkPolicyClient.AddEventHandler(parentHandler)
 
 	// Register controller extensions
 	if controllerExtension != nil {
@@ -290,6 +299,7 @@ func (r *gatewayReconciler) Start(ctx context.Context) error {
 		r.svcAccountClient.HasSynced,
 		r.svcClient.HasSynced,
 		r.configMapClient.HasSynced,
+		r.networkPolicyClient.HasSynced,
 	}
 	// Add GatewayParameters cache sync handlers (includes both gwParamClient and agwParamClient)
 	hasSynced = append(hasSynced, r.gwParams.GetCacheSyncHandlers()...)
@@ -310,6 +320,7 @@ func (r *gatewayReconciler) Start(ctx context.Context) error {
 		r.svcAccountClient,
 		r.svcClient,
 		r.configMapClient,
+		r.networkPolicyClient,
 	}
 	if r.gwParamClient != nil {
 		clients = append(clients, r.gwParamClient)
@@ -371,6 +382,11 @@ func (r *gatewayReconciler) Reconcile(req types.NamespacedName) (rErr error) {
 		// if we fail to either reference a valid GatewayParameters or
 		// the GatewayParameters configuration leads to issues building the
 		// objects, we want to set the status to InvalidParameters.
+		// This includes field errors from validating the GatewayParameters of the GatewayClass. They are reported on
+		// the Gateway rather than the GatewayClass because the parameters that are deployed are the GatewayClass
+		// defaults merged with the Gateway's own parametersRef, so validity is only known per Gateway, and the
+		// GatewayClass status is owned by the GatewayClass controller, which does not resolve parameters.
+		r.events.InvalidParameters(gw, err)
 		condition := metav1.Condition{
 			Type:               string(gwv1.GatewayConditionAccepted),
 			Status:             metav1.ConditionFalse,
@@ -398,10 +414,28 @@ func (r *gatewayReconciler) Reconcile(req types.NamespacedName) (rErr error) {
 		}
 	}
 	objs = r.deployer.SetNamespaceAndOwnerWithGVK(gw, wellknown.GatewayGVK, objs)
-	err = r.deployer.DeployObjsWithSource(ctx, objs, gw)
+	deployed, err := r.deployer.DeployObjsWithSource(ctx, objs, gw)
 	if err != nil {
+		r.events.DeployFailed(gw, err)
 		return err
 	}
+	// delete the conditionally provisioned objects which are no longer provisioned, e.g. a disabled NetworkPolicy
+	pruned := false
+	objsToPrune, err := r.deployer.GetObjsToPrune(ctx, gw)
+	if err == nil {
+		objsToPrune = slices.DeleteFunc(objsToPrune, func(obj client.Object) bool {
+			return !r.mayExist(obj)
+		})
+		pruned, err = r.deployer.PruneObjs(ctx, gw, objsToPrune)
+	}
+	if err != nil {
+		r.events.DeployFailed(gw, err)
+		return err
+	}
+	// only record an event when the proxy objects actually changed, so that resyncs do not flood the Gateway with events
+	if deployed || pruned {
+		r.events.Deployed(gw)
+	}
 
 	// find the name/ns of the service we own so we can grab addresses
 	// from it for status
@@ -421,6 +455,17 @@ func (r *gatewayReconciler) Reconcile(req types.NamespacedName) (rErr error) {
 	return nil
 }
 
+// mayExist reports whether obj may exist in the cluster. Objects of watched kinds are looked up in the informer
+// cache, so that pruning an object which was never provisioned does not cost a request on every reconcile.
+func (r *gatewayReconciler) mayExist(obj client.Object) bool {
+	switch obj.(type) {
+	case *networkingv1.NetworkPolicy:
+		return r.networkPolicyClient.Get(obj.GetName(), obj.GetNamespace()) != nil
+	default:
+		return true
+	}
+}
+
 func (r *gatewayReconciler) updateStatus(ctx context.Context, gw *gwv1.Gateway, svcMeta *metav1.ObjectMeta) error {
 	var svc *corev1.Service
 	if svcMeta != nil {
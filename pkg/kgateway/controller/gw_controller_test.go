@@ -0,0 +1,217 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"istio.io/istio/pkg/kube/kclient"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/apiclient"
+	apiclientfake "github.com/kgateway-dev/kgateway/v2/pkg/apiclient/fake"
+	"github.com/kgateway-dev/kgateway/v2/pkg/deployer"
+	internaldeployer "github.com/kgateway-dev/kgateway/v2/pkg/kgateway/deployer"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
+	"github.com/kgateway-dev/kgateway/v2/pkg/schemes"
+	deployertest "github.com/kgateway-dev/kgateway/v2/test/deployer"
+)
+
+func TestGatewayReconcilerPrunesNetworkPolicy(t *testing.T) {
+	tests := []struct {
+		name          string
+		networkPolicy bool
+		wantGet       bool
+	}{
+		{
+			name:          "deletes a previously provisioned NetworkPolicy",
+			networkPolicy: true,
+			wantGet:       true,
+		},
+		{
+			name: "does not look up a NetworkPolicy which is not in the cache",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gw := testGateway()
+			objs := []client.Object{testGatewayClass(), testGatewayParameters(&kgateway.KubernetesProxyConfig{
+				NetworkPolicyEnabled: ptr.To(false),
+			}), gw, testProxyService(gw)}
+			if tt.networkPolicy {
+				objs = append(objs, &networkingv1.NetworkPolicy{
+					TypeMeta: metav1.TypeMeta{
+						APIVersion: wellknown.NetworkPolicyGVK.GroupVersion().String(),
+						Kind:       wellknown.NetworkPolicyGVK.Kind,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            gw.Name,
+						Namespace:       gw.Namespace,
+						OwnerReferences: []metav1.OwnerReference{gatewayOwnerReference(gw)},
+					},
+				})
+			}
+			r, fakeClient := newTestGatewayReconciler(t, record.NewFakeRecorder(10), nil, objs...)
+
+			err := r.Reconcile(types.NamespacedName{Name: gw.Name, Namespace: gw.Namespace})
+			require.NoError(t, err)
+
+			var gets int
+			for _, action := range fakeClient.Dynamic().(*fake.FakeDynamicClient).Actions() {
+				if action.GetVerb() == "get" && action.GetResource() == wellknown.NetworkPolicyGVR {
+					gets++
+				}
+			}
+			if !tt.wantGet {
+				require.Zero(t, gets, "expected the NetworkPolicy to be looked up in the cache only")
+				return
+			}
+			require.Equal(t, 1, gets)
+			_, err = fakeClient.Dynamic().Resource(wellknown.NetworkPolicyGVR).Namespace(gw.Namespace).Get(t.Context(), gw.Name, metav1.GetOptions{})
+			require.True(t, apierrors.IsNotFound(err), "expected the NetworkPolicy to be deleted, got: %v", err)
+		})
+	}
+}
+
+// newTestGatewayReconciler returns a gatewayReconciler backed by a fake client seeded with objs, which must include
+// the Gateway and its GatewayClass. Applying the proxy objects is stubbed out, and fails with patchErr if it is set.
+func newTestGatewayReconciler(
+	t *testing.T,
+	recorder record.EventRecorder,
+	patchErr error,
+	objs ...client.Object,
+) (*gatewayReconciler, apiclient.Client) {
+	t.Helper()
+
+	fakeClient := apiclientfake.NewClient(t, objs...)
+	var gwObjs []client.Object
+	for _, obj := range objs {
+		switch obj.(type) {
+		case *gwv1.GatewayClass, *gwv1.Gateway:
+			gwObjs = append(gwObjs, obj)
+		}
+	}
+	gwp := internaldeployer.NewGatewayParameters(fakeClient, &deployer.Inputs{
+		CommonCollections: deployertest.NewCommonCols(t, gwObjs...),
+		ControlPlane: deployer.ControlPlaneInfo{
+			XdsHost:    "something.cluster.local",
+			XdsPort:    1234,
+			AgwXdsPort: 5678,
+		},
+		ImageInfo: &deployer.ImageInfo{
+			Registry: "foo",
+			Tag:      "bar",
+		},
+		GatewayClassName:           wellknown.DefaultGatewayClassName,
+		WaypointGatewayClassName:   wellknown.DefaultWaypointClassName,
+		AgentgatewayClassName:      wellknown.DefaultAgwClassName,
+		AgentgatewayControllerName: wellknown.DefaultAgwControllerName,
+	})
+	d, err := internaldeployer.NewGatewayDeployer(
+		wellknown.DefaultGatewayControllerName,
+		wellknown.DefaultAgwControllerName,
+		wellknown.DefaultAgwClassName,
+		schemes.GatewayScheme(),
+		fakeClient,
+		gwp,
+		deployer.WithPatcher(func(apiclient.Client, string, schema.GroupVersionResource, string, string, []byte, ...string) error {
+			return patchErr
+		}),
+	)
+	require.NoError(t, err)
+
+	r := &gatewayReconciler{
+		deployer:            d,
+		gwParams:            gwp,
+		controllerName:      wellknown.DefaultGatewayControllerName,
+		enableEnvoy:         true,
+		gwClient:            kclient.New[*gwv1.Gateway](fakeClient),
+		gwClassClient:       kclient.New[*gwv1.GatewayClass](fakeClient),
+		svcClient:           kclient.New[*corev1.Service](fakeClient),
+		networkPolicyClient: kclient.New[*networkingv1.NetworkPolicy](fakeClient),
+		events:              NewGatewayEventRecorder(recorder),
+	}
+	fakeClient.RunAndWait(t.Context().Done())
+	return r, fakeClient
+}
+
+func testGatewayClass() *gwv1.GatewayClass {
+	return &gwv1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: wellknown.DefaultGatewayClassName,
+		},
+		Spec: gwv1.GatewayClassSpec{
+			ControllerName: wellknown.DefaultGatewayControllerName,
+			ParametersRef: &gwv1.ParametersReference{
+				Group:     kgateway.GroupName,
+				Kind:      gwv1.Kind(wellknown.GatewayParametersGVK.Kind),
+				Name:      wellknown.DefaultGatewayParametersName,
+				Namespace: ptr.To(gwv1.Namespace(defaultNamespace)),
+			},
+		},
+	}
+}
+
+func testGatewayParameters(kube *kgateway.KubernetesProxyConfig) *kgateway.GatewayParameters {
+	return &kgateway.GatewayParameters{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      wellknown.DefaultGatewayParametersName,
+			Namespace: defaultNamespace,
+		},
+		Spec: kgateway.GatewayParametersSpec{
+			Kube: kube,
+		},
+	}
+}
+
+func testGateway() *gwv1.Gateway {
+	return &gwv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "gw",
+			Namespace: defaultNamespace,
+			UID:       "1235",
+		},
+		Spec: gwv1.GatewaySpec{
+			GatewayClassName: wellknown.DefaultGatewayClassName,
+			Listeners: []gwv1.Listener{{
+				Name:     "http",
+				Protocol: gwv1.HTTPProtocolType,
+				Port:     80,
+			}},
+		},
+	}
+}
+
+// testProxyService returns the proxy Service of gw. Applying the proxy objects is stubbed out in these tests,
+// so the Service the reconciler reads the Gateway addresses from is created up front.
+func testProxyService(gw *gwv1.Gateway) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            gw.Name,
+			Namespace:       gw.Namespace,
+			OwnerReferences: []metav1.OwnerReference{gatewayOwnerReference(gw)},
+		},
+		Spec: corev1.ServiceSpec{ClusterIP: "10.0.0.1"},
+	}
+}
+
+func gatewayOwnerReference(gw *gwv1.Gateway) metav1.OwnerReference {
+	return metav1.OwnerReference{
+		APIVersion: gwv1.GroupVersion.String(),
+		Kind:       wellknown.GatewayKind,
+		Name:       gw.Name,
+		UID:        gw.UID,
+		Controller: ptr.To(true),
+	}
+}
@@ -9,7 +9,10 @@ import (
 
 	"helm.sh/helm/v3/pkg/chart"
 	"istio.io/istio/pkg/kube/kclient"
+	appsv1 "k8s.io/api/apps/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -29,6 +32,9 @@ var (
 
 	// ErrNotFound is returned when a requested resource is not found
 	ErrNotFound = errors.New("resource not found")
+
+	// ErrInvalidParameters is returned when the GatewayParameters referenced by a Gateway or its GatewayClass fail validation
+	ErrInvalidParameters = errors.New("invalid GatewayParameters")
 )
 
 func NewGatewayParameters(cli apiclient.Client, inputs *deployer.Inputs) *GatewayParameters {
@@ -118,7 +124,8 @@ func (gp *GatewayParameters) GetCacheSyncHandlers() []cache.InformerSynced {
 }
 
 // PostProcessObjects implements deployer.ObjectPostProcessor.
-// It applies AgentgatewayParameters overlays to the rendered objects.
+// For envoy Gateways, it adds the NetworkPolicy for the proxy pods when it is enabled.
+// For agentgateway Gateways, it applies AgentgatewayParameters overlays to the rendered objects.
 // When both GatewayClass and Gateway have AgentgatewayParameters, the overlays
 // are applied in order: GatewayClass first, then Gateway on top.
 func (gp *GatewayParameters) PostProcessObjects(ctx context.Context, obj client.Object, rendered []client.Object) ([]client.Object, error) {
@@ -131,7 +138,22 @@ func (gp *GatewayParameters) PostProcessObjects(ctx context.Context, obj client.
 
 	// Fall back to default implementation
 	gw, ok := obj.(*gwv1.Gateway)
-	if !ok || gp.agwHelmValuesGenerator == nil {
+	if !ok {
+		return rendered, nil
+	}
+
+	if gp.isEnvoyGateway(gw) {
+		networkPolicy, err := gp.kgwParameters.getNetworkPolicy(gw)
+		if err != nil {
+			return nil, err
+		}
+		if networkPolicy != nil {
+			rendered = append(rendered, networkPolicy)
+		}
+		return rendered, nil
+	}
+
+	if gp.agwHelmValuesGenerator == nil {
 		return rendered, nil
 	}
 
@@ -160,6 +182,37 @@ func (gp *GatewayParameters) PostProcessObjects(ctx context.Context, obj client.
 	return rendered, nil
 }
 
+// GetObjsToPrune implements deployer.ObjectPruner.
+// It returns the NetworkPolicy of an envoy Gateway when it is not enabled, so that a NetworkPolicy
+// provisioned while it was enabled stops restricting ingress to the proxy pods once it is disabled.
+func (gp *GatewayParameters) GetObjsToPrune(ctx context.Context, obj client.Object) ([]client.Object, error) {
+	gw, ok := obj.(*gwv1.Gateway)
+	if !ok || !gp.isEnvoyGateway(gw) {
+		return nil, nil
+	}
+
+	networkPolicy, err := gp.kgwParameters.getNetworkPolicy(gw)
+	if err != nil || networkPolicy != nil {
+		return nil, err
+	}
+	return []client.Object{&networkingv1.NetworkPolicy{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: wellknown.NetworkPolicyGVK.GroupVersion().String(),
+			Kind:       wellknown.NetworkPolicyGVK.Kind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      gw.GetName(),
+			Namespace: gw.GetNamespace(),
+		},
+	}}, nil
+}
+
+// isEnvoyGateway returns whether the helm values of the Gateway are generated from its GatewayParameters
+func (gp *GatewayParameters) isEnvoyGateway(gw *gwv1.Gateway) bool {
+	generator, err := gp.getHelmValuesGenerator(gw)
+	return err == nil && gp.kgwParameters != nil && generator == deployer.HelmValuesGenerator(gp.kgwParameters)
+}
+
 func GatewayReleaseNameAndNamespace(obj client.Object) (string, string) {
 	return obj.GetName(), obj.GetNamespace()
 }
@@ -295,6 +348,9 @@ func (k *kgatewayParameters) getGatewayParametersForGateway(gw *gwv1.Gateway) (*
 	if gwp == nil {
 		return nil, deployer.GetGatewayParametersForGatewayError(ErrNotFound, gwpNamespace, gwpName, gw.GetNamespace(), gw.GetName(), "Gateway")
 	}
+	if err := validateGatewayParameters(gwp); err != nil {
+		return nil, err
+	}
 
 	defaultGwp, err := k.getDefaultGatewayParameters(gw)
 	if err != nil {
@@ -315,6 +371,16 @@ func (k *kgatewayParameters) getGatewayParametersForGateway(gw *gwv1.Gateway) (*
 	return mergedGwp, nil
 }
 
+// getNetworkPolicy returns the NetworkPolicy for the proxy pods of the Gateway, or nil if it is not enabled
+func (k *kgatewayParameters) getNetworkPolicy(gw *gwv1.Gateway) (*networkingv1.NetworkPolicy, error) {
+	gwParam, err := k.getGatewayParametersForGateway(gw)
+	if err != nil {
+		return nil, err
+	}
+	ports := deployer.GetPortsValues(deployer.GetGatewayIR(gw, k.inputs.CommonCollections), gwParam, false)
+	return buildNetworkPolicy(gw, gwParam, ports), nil
+}
+
 // gets the default GatewayParameters associated with the GatewayClass of the provided Gateway
 func (k *kgatewayParameters) getDefaultGatewayParameters(gw *gwv1.Gateway) (*kgateway.GatewayParameters, error) {
 	gwc, err := getGatewayClassFromGateway(k.gwClassClient, gw)
@@ -379,6 +445,9 @@ func (k *kgatewayParameters) getGatewayParametersForGatewayClass(gwc *gwv1.Gatew
 			"GatewayClass",
 		)
 	}
+	if err := validateGatewayParameters(gwp); err != nil {
+		return nil, err
+	}
 
 	// merge the explicit GatewayParameters with the defaults. this is
 	// primarily done to ensure that the image registry and tag are
@@ -554,3 +623,51 @@ func translateInfraMeta[K ~string, V ~string](meta map[K]V) map[string]string {
 	}
 	return infra
 }
+
+// ValidateGatewayParameters checks the provided GatewayParameters for field combinations that
+// cannot be rendered into a working proxy deployment. The CRD schema catches most invalid
+// values, so this only covers constraints that span multiple fields.
+// Resource requests exceeding their limits are deliberately not rejected here: such parameters
+// were previously deployed as is, and the Deployment is already validated by the API server.
+// It validates a single GatewayParameters object as written by the user: merging with the
+// GatewayClass defaults can both hide and introduce conflicts, e.g. a selfManaged override
+// clears the kube config it is merged onto.
+// The returned errors carry the offending field path so they can be surfaced on the
+// Gateway's InvalidParameters condition.
+func ValidateGatewayParameters(gwp *kgateway.GatewayParameters) field.ErrorList {
+	if gwp == nil {
+		return nil
+	}
+
+	var errs field.ErrorList
+	specPath := field.NewPath("spec")
+	if gwp.Spec.Kube != nil && gwp.Spec.SelfManaged != nil {
+		errs = append(errs, field.Forbidden(specPath.Child("selfManaged"), "may not be specified together with kube"))
+	}
+
+	kube := gwp.Spec.Kube
+	if kube == nil {
+		return errs
+	}
+	kubePath := specPath.Child("kube")
+
+	deployPath := kubePath.Child("deployment")
+	if replicas := kube.GetDeployment().GetReplicas(); replicas != nil && *replicas < 0 {
+		errs = append(errs, field.Invalid(deployPath.Child("replicas"), *replicas, "must be greater than or equal to 0"))
+	}
+	if strategy := kube.GetDeployment().GetStrategy(); strategy != nil &&
+		strategy.Type == appsv1.RecreateDeploymentStrategyType && strategy.RollingUpdate != nil {
+		errs = append(errs, field.Forbidden(deployPath.Child("strategy", "rollingUpdate"), "may not be specified when strategy type is Recreate"))
+	}
+
+	return errs
+}
+
+// validateGatewayParameters validates a GatewayParameters object as written by the user, before it is merged with
+// the defaults of its GatewayClass, and wraps any field errors in ErrInvalidParameters.
+func validateGatewayParameters(gwp *kgateway.GatewayParameters) error {
+	if errs := ValidateGatewayParameters(gwp); len(errs) > 0 {
+		return fmt.Errorf("%w %s/%s: %w", ErrInvalidParameters, gwp.GetNamespace(), gwp.GetName(), errs.ToAggregate())
+	}
+	return nil
+}
@@ -9,7 +9,9 @@ import (
 	"istio.io/istio/pkg/kube/krt/krttest"
 	"istio.io/istio/pkg/test"
 	"istio.io/istio/pkg/util/smallset"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/utils/ptr"
@@ -112,6 +114,297 @@ func TestShouldUseExtendedGatewayParameters(t *testing.T) {
 	assert.Contains(t, vals, "testHelmValuesGenerator")
 }
 
+func TestGatewayParametersInheritGatewayClassDefaults(t *testing.T) {
+	gwc := defaultGatewayClass()
+	classParams := emptyGatewayParameters()
+	classParams.Spec.Kube = &kgateway.KubernetesProxyConfig{
+		Deployment: &kgateway.ProxyDeployment{
+			Replicas: ptr.To[int32](3),
+		},
+		EnvoyContainer: &kgateway.EnvoyContainer{
+			Bootstrap: &kgateway.EnvoyBootstrap{
+				LogLevel: ptr.To("debug"),
+			},
+		},
+	}
+	gwParams := &kgateway.GatewayParameters{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "gw-params",
+			Namespace: defaultNamespace,
+			UID:       "1238",
+		},
+		Spec: kgateway.GatewayParametersSpec{
+			Kube: &kgateway.KubernetesProxyConfig{
+				Deployment: &kgateway.ProxyDeployment{
+					Replicas: ptr.To[int32](1),
+				},
+			},
+		},
+	}
+
+	gw := &gwv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: defaultNamespace,
+			UID:       "1235",
+		},
+		Spec: gwv1.GatewaySpec{
+			GatewayClassName: wellknown.DefaultGatewayClassName,
+			Infrastructure: &gwv1.GatewayInfrastructure{
+				ParametersRef: &gwv1.LocalParametersReference{
+					Group: kgateway.GroupName,
+					Kind:  gwv1.Kind(wellknown.GatewayParametersGVK.Kind),
+					Name:  gwParams.GetName(),
+				},
+			},
+		},
+	}
+
+	ctx := t.Context()
+	fakeClient := fake.NewClient(t, gwc, classParams, gwParams)
+	kgwp := newkgatewayParameters(fakeClient, defaultInputs(t, gwc, gw))
+	fakeClient.RunAndWait(ctx.Done())
+
+	merged, err := kgwp.getGatewayParametersForGateway(gw)
+	assert.NoError(t, err)
+	// the Gateway's value takes precedence over the GatewayClass default
+	assert.Equal(t, ptr.To[int32](1), merged.Spec.Kube.GetDeployment().GetReplicas())
+	// values the Gateway does not set are inherited from the GatewayClass
+	assert.Equal(t, ptr.To("debug"), merged.Spec.Kube.GetEnvoyContainer().GetBootstrap().GetLogLevel())
+	// and values neither sets fall back to the built-in defaults
+	assert.Equal(t, ptr.To("foo"), merged.Spec.Kube.GetEnvoyContainer().GetImage().GetRegistry())
+}
+
+func TestShouldRejectInvalidGatewayParameters(t *testing.T) {
+	gwc := defaultGatewayClass()
+	gwParams := emptyGatewayParameters()
+	gwParams.Spec.Kube = &kgateway.KubernetesProxyConfig{
+		Deployment: &kgateway.ProxyDeployment{
+			Strategy: &appsv1.DeploymentStrategy{
+				Type:          appsv1.RecreateDeploymentStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDeployment{},
+			},
+		},
+	}
+
+	gw := &gwv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: defaultNamespace,
+			UID:       "1235",
+		},
+		Spec: gwv1.GatewaySpec{
+			GatewayClassName: wellknown.DefaultGatewayClassName,
+			Listeners: []gwv1.Listener{
+				{
+					Protocol: gwv1.HTTPProtocolType,
+					Port:     80,
+					Name:     "http",
+				},
+			},
+		},
+	}
+
+	ctx := t.Context()
+	fakeClient := fake.NewClient(t, gwc, gwParams)
+	gwp := NewGatewayParameters(fakeClient, defaultInputs(t, gwc, gw))
+	fakeClient.RunAndWait(ctx.Done())
+	_, err := gwp.GetValues(ctx, gw)
+
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+	assert.ErrorContains(t, err, "spec.kube.deployment.strategy.rollingUpdate")
+}
+
+func TestValidateGatewayParametersBeforeMerge(t *testing.T) {
+	gwParamsRef := &gwv1.GatewayInfrastructure{
+		ParametersRef: &gwv1.LocalParametersReference{
+			Group: kgateway.GroupName,
+			Kind:  gwv1.Kind(wellknown.GatewayParametersGVK.Kind),
+			Name:  "gw-params",
+		},
+	}
+	invalidKube := &kgateway.KubernetesProxyConfig{
+		Deployment: &kgateway.ProxyDeployment{Replicas: ptr.To[int32](-1)},
+	}
+
+	tests := []struct {
+		name          string
+		classSpec     kgateway.GatewayParametersSpec
+		gatewaySpec   *kgateway.GatewayParametersSpec
+		wantErrSubstr string
+	}{
+		{
+			name:          "invalid GatewayClass parameters",
+			classSpec:     kgateway.GatewayParametersSpec{Kube: invalidKube},
+			wantErrSubstr: defaultNamespace + "/" + wellknown.DefaultGatewayParametersName + ": spec.kube.deployment.replicas",
+		},
+		{
+			name:          "invalid Gateway parameters",
+			gatewaySpec:   &kgateway.GatewayParametersSpec{Kube: invalidKube},
+			wantErrSubstr: defaultNamespace + "/gw-params: spec.kube.deployment.replicas",
+		},
+		{
+			name: "Gateway parameters with both kube and self managed",
+			gatewaySpec: &kgateway.GatewayParametersSpec{
+				Kube:        &kgateway.KubernetesProxyConfig{},
+				SelfManaged: &kgateway.SelfManagedGateway{},
+			},
+			wantErrSubstr: defaultNamespace + "/gw-params: spec.selfManaged",
+		},
+		{
+			name:      "self managed GatewayClass default with a kube Gateway override",
+			classSpec: kgateway.GatewayParametersSpec{SelfManaged: &kgateway.SelfManagedGateway{}},
+			gatewaySpec: &kgateway.GatewayParametersSpec{
+				Kube: &kgateway.KubernetesProxyConfig{
+					Deployment: &kgateway.ProxyDeployment{Replicas: ptr.To[int32](2)},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gwc := defaultGatewayClass()
+			classParams := emptyGatewayParameters()
+			classParams.Spec = tt.classSpec
+			objs := []client.Object{gwc, classParams}
+
+			gw := &gwv1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo",
+					Namespace: defaultNamespace,
+					UID:       "1235",
+				},
+				Spec: gwv1.GatewaySpec{
+					GatewayClassName: wellknown.DefaultGatewayClassName,
+					Listeners: []gwv1.Listener{
+						{
+							Protocol: gwv1.HTTPProtocolType,
+							Port:     80,
+							Name:     "http",
+						},
+					},
+				},
+			}
+			if tt.gatewaySpec != nil {
+				gw.Spec.Infrastructure = gwParamsRef
+				objs = append(objs, &kgateway.GatewayParameters{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "gw-params",
+						Namespace: defaultNamespace,
+					},
+					Spec: *tt.gatewaySpec,
+				})
+			}
+
+			ctx := t.Context()
+			fakeClient := fake.NewClient(t, objs...)
+			gwp := NewGatewayParameters(fakeClient, defaultInputs(t, gwc, gw))
+			fakeClient.RunAndWait(ctx.Done())
+			_, err := gwp.GetValues(ctx, gw)
+
+			if tt.wantErrSubstr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, ErrInvalidParameters)
+			assert.ErrorContains(t, err, tt.wantErrSubstr)
+		})
+	}
+}
+
+func TestValidateGatewayParameters(t *testing.T) {
+	tests := []struct {
+		name       string
+		spec       kgateway.GatewayParametersSpec
+		wantFields []string
+	}{
+		{
+			name: "empty kube config is valid",
+			spec: kgateway.GatewayParametersSpec{
+				Kube: &kgateway.KubernetesProxyConfig{},
+			},
+		},
+		{
+			name: "self managed is valid",
+			spec: kgateway.GatewayParametersSpec{
+				SelfManaged: &kgateway.SelfManagedGateway{},
+			},
+		},
+		{
+			name: "kube and self managed are mutually exclusive",
+			spec: kgateway.GatewayParametersSpec{
+				Kube:        &kgateway.KubernetesProxyConfig{},
+				SelfManaged: &kgateway.SelfManagedGateway{},
+			},
+			wantFields: []string{"spec.selfManaged"},
+		},
+		{
+			name: "negative replicas",
+			spec: kgateway.GatewayParametersSpec{
+				Kube: &kgateway.KubernetesProxyConfig{
+					Deployment: &kgateway.ProxyDeployment{Replicas: ptr.To[int32](-1)},
+				},
+			},
+			wantFields: []string{"spec.kube.deployment.replicas"},
+		},
+		{
+			name: "recreate strategy with rolling update",
+			spec: kgateway.GatewayParametersSpec{
+				Kube: &kgateway.KubernetesProxyConfig{
+					Deployment: &kgateway.ProxyDeployment{
+						Strategy: &appsv1.DeploymentStrategy{
+							Type:          appsv1.RecreateDeploymentStrategyType,
+							RollingUpdate: &appsv1.RollingUpdateDeployment{},
+						},
+					},
+				},
+			},
+			wantFields: []string{"spec.kube.deployment.strategy.rollingUpdate"},
+		},
+		{
+			name: "requests exceeding limits are not rejected",
+			spec: kgateway.GatewayParametersSpec{
+				Kube: &kgateway.KubernetesProxyConfig{
+					EnvoyContainer: &kgateway.EnvoyContainer{
+						Resources: &corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("2"),
+								corev1.ResourceMemory: resource.MustParse("1Gi"),
+							},
+							Limits: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("1"),
+								corev1.ResourceMemory: resource.MustParse("2Gi"),
+							},
+						},
+					},
+					SdsContainer: &kgateway.SdsContainer{
+						Resources: &corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceMemory: resource.MustParse("256Mi"),
+							},
+							Limits: corev1.ResourceList{
+								corev1.ResourceMemory: resource.MustParse("128Mi"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := ValidateGatewayParameters(&kgateway.GatewayParameters{Spec: tt.spec})
+			var gotFields []string
+			for _, err := range errs {
+				gotFields = append(gotFields, err.Field)
+			}
+			assert.Equal(t, tt.wantFields, gotFields)
+		})
+	}
+}
+
 func defaultGatewayClass() *gwv1.GatewayClass {
 	return &gwv1.GatewayClass{
 		ObjectMeta: metav1.ObjectMeta{
@@ -0,0 +1,95 @@
+package deployer
+
+import (
+	"maps"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/deployer"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
+)
+
+// envoyStatsPort is the port of the stats listener of the envoy proxy, as configured by the envoy chart
+const envoyStatsPort = 9091
+
+// buildNetworkPolicy returns the NetworkPolicy which only allows ingress traffic to the proxy pods of the Gateway
+// on the provided listener ports, from the allowed peers of the GatewayParameters if any, along with the stats port
+// when stats are enabled so that metrics can still be scraped.
+// It returns nil if the GatewayParameters do not enable the NetworkPolicy.
+// The listener ports are passed in rather than read from the Gateway spec, since the ports of the proxy also include
+// the listeners of attached ListenerSets, and privileged listener ports are mapped to a different container port.
+// Building the policy cannot fail, so unlike rendering the rest of the proxy objects it does not return an error.
+func buildNetworkPolicy(gw *gwv1.Gateway, params *kgateway.GatewayParameters, ports []deployer.HelmPort) *networkingv1.NetworkPolicy {
+	kube := params.Spec.GetKube()
+	if !ptr.Deref(kube.GetNetworkPolicyEnabled(), false) {
+		return nil
+	}
+
+	var ingress []networkingv1.NetworkPolicyIngressRule
+	if len(ports) > 0 {
+		listenerPorts := make([]networkingv1.NetworkPolicyPort, 0, len(ports))
+		for _, port := range ports {
+			listenerPorts = append(listenerPorts, networkingv1.NetworkPolicyPort{
+				Protocol: ptr.To(corev1.Protocol(ptr.Deref(port.Protocol, string(corev1.ProtocolTCP)))),
+				Port:     ptr.To(intstr.FromInt32(ptr.Deref(port.TargetPort, ptr.Deref(port.Port, 0)))),
+			})
+		}
+		ingress = append(ingress, networkingv1.NetworkPolicyIngressRule{
+			Ports: listenerPorts,
+			From:  kube.GetNetworkPolicyAllowedPeers(),
+		})
+	}
+	// the stats port is not restricted to the allowed peers, as metrics are scraped from outside the data path
+	if ptr.Deref(kube.GetStats().GetEnabled(), false) {
+		ingress = append(ingress, networkingv1.NetworkPolicyIngressRule{
+			Ports: []networkingv1.NetworkPolicyPort{{
+				Protocol: ptr.To(corev1.ProtocolTCP),
+				Port:     ptr.To(intstr.FromInt32(envoyStatsPort)),
+			}},
+		})
+	}
+
+	// the selector labels of the proxy pods, as rendered by the envoy chart
+	selectorLabels := map[string]string{
+		"app.kubernetes.io/name":     gw.GetName(),
+		"app.kubernetes.io/instance": gw.GetName(),
+		wellknown.GatewayNameLabel:   gw.GetName(),
+	}
+	labels := map[string]string{
+		"kgateway":                      "kube-gateway",
+		"app.kubernetes.io/managed-by":  "kgateway",
+		wellknown.GatewayClassNameLabel: string(gw.Spec.GatewayClassName),
+	}
+	var annotations map[string]string
+	if i := gw.Spec.Infrastructure; i != nil {
+		maps.Copy(labels, translateInfraMeta(i.Labels))
+		annotations = translateInfraMeta(i.Annotations)
+	}
+	maps.Copy(labels, selectorLabels)
+
+	return &networkingv1.NetworkPolicy{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: wellknown.NetworkPolicyGVK.GroupVersion().String(),
+			Kind:       wellknown.NetworkPolicyGVK.Kind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        gw.GetName(),
+			Namespace:   gw.GetNamespace(),
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: selectorLabels,
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress:     ingress,
+		},
+	}
+}
@@ -0,0 +1,170 @@
+package deployer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/apiclient/fake"
+	"github.com/kgateway-dev/kgateway/v2/pkg/deployer"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
+)
+
+func TestBuildNetworkPolicy(t *testing.T) {
+	gw := &gwv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "gw",
+			Namespace: defaultNamespace,
+		},
+		Spec: gwv1.GatewaySpec{
+			GatewayClassName: wellknown.DefaultGatewayClassName,
+			Listeners: []gwv1.Listener{
+				{Name: "http", Port: 8080, Protocol: gwv1.HTTPProtocolType},
+				{Name: "tcp", Port: 9000, Protocol: gwv1.TCPProtocolType},
+			},
+		},
+	}
+	ports := []deployer.HelmPort{}
+	for _, l := range gw.Spec.Listeners {
+		ports = deployer.AppendPortValue(ports, int32(l.Port), string(l.Name), nil)
+	}
+	policyPort := func(port int32) networkingv1.NetworkPolicyPort {
+		return networkingv1.NetworkPolicyPort{
+			Protocol: ptr.To(corev1.ProtocolTCP),
+			Port:     ptr.To(intstr.FromInt32(port)),
+		}
+	}
+
+	listenerPorts := []networkingv1.NetworkPolicyPort{policyPort(8080), policyPort(9000)}
+	allowedPeers := []networkingv1.NetworkPolicyPeer{{
+		NamespaceSelector: &metav1.LabelSelector{},
+		PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": "client"}},
+	}}
+
+	tests := []struct {
+		name        string
+		kube        *kgateway.KubernetesProxyConfig
+		wantIngress []networkingv1.NetworkPolicyIngressRule
+	}{
+		{
+			name: "unset",
+			kube: &kgateway.KubernetesProxyConfig{},
+		},
+		{
+			name: "disabled",
+			kube: &kgateway.KubernetesProxyConfig{
+				NetworkPolicyEnabled:      ptr.To(false),
+				NetworkPolicyAllowedPeers: allowedPeers,
+			},
+		},
+		{
+			name: "enabled allows the listener ports",
+			kube: &kgateway.KubernetesProxyConfig{
+				NetworkPolicyEnabled: ptr.To(true),
+			},
+			wantIngress: []networkingv1.NetworkPolicyIngressRule{{Ports: listenerPorts}},
+		},
+		{
+			name: "enabled with stats allows the stats port",
+			kube: &kgateway.KubernetesProxyConfig{
+				NetworkPolicyEnabled: ptr.To(true),
+				Stats:                &kgateway.StatsConfig{Enabled: ptr.To(true)},
+			},
+			wantIngress: []networkingv1.NetworkPolicyIngressRule{
+				{Ports: listenerPorts},
+				{Ports: []networkingv1.NetworkPolicyPort{policyPort(envoyStatsPort)}},
+			},
+		},
+		{
+			name: "allowed peers only restrict the listener ports",
+			kube: &kgateway.KubernetesProxyConfig{
+				NetworkPolicyEnabled:      ptr.To(true),
+				NetworkPolicyAllowedPeers: allowedPeers,
+				Stats:                     &kgateway.StatsConfig{Enabled: ptr.To(true)},
+			},
+			wantIngress: []networkingv1.NetworkPolicyIngressRule{
+				{Ports: listenerPorts, From: allowedPeers},
+				{Ports: []networkingv1.NetworkPolicyPort{policyPort(envoyStatsPort)}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := &kgateway.GatewayParameters{Spec: kgateway.GatewayParametersSpec{Kube: tt.kube}}
+			got := buildNetworkPolicy(gw, params, ports)
+			if tt.wantIngress == nil {
+				assert.Nil(t, got)
+				return
+			}
+
+			require.NotNil(t, got)
+			assert.Equal(t, "gw", got.Name)
+			assert.Equal(t, defaultNamespace, got.Namespace)
+			assert.Equal(t, map[string]string{
+				"app.kubernetes.io/name":     "gw",
+				"app.kubernetes.io/instance": "gw",
+				wellknown.GatewayNameLabel:   "gw",
+			}, got.Spec.PodSelector.MatchLabels)
+			assert.Equal(t, []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}, got.Spec.PolicyTypes)
+			assert.Equal(t, tt.wantIngress, got.Spec.Ingress)
+		})
+	}
+}
+
+func TestGetObjsToPruneNetworkPolicy(t *testing.T) {
+	tests := []struct {
+		name      string
+		enabled   *bool
+		wantPrune bool
+	}{
+		{name: "unset", wantPrune: true},
+		{name: "disabled", enabled: ptr.To(false), wantPrune: true},
+		{name: "enabled", enabled: ptr.To(true)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gwc := defaultGatewayClass()
+			gwParams := emptyGatewayParameters()
+			gwParams.Spec.Kube = &kgateway.KubernetesProxyConfig{NetworkPolicyEnabled: tt.enabled}
+			gw := &gwv1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo",
+					Namespace: defaultNamespace,
+					UID:       "1235",
+				},
+				Spec: gwv1.GatewaySpec{
+					GatewayClassName: wellknown.DefaultGatewayClassName,
+					Listeners: []gwv1.Listener{
+						{Name: "http", Port: 80, Protocol: gwv1.HTTPProtocolType},
+					},
+				},
+			}
+
+			ctx := t.Context()
+			fakeClient := fake.NewClient(t, gwc, gwParams)
+			gwp := NewGatewayParameters(fakeClient, defaultInputs(t, gwc, gw))
+			fakeClient.RunAndWait(ctx.Done())
+
+			objs, err := gwp.GetObjsToPrune(ctx, gw)
+			require.NoError(t, err)
+			if !tt.wantPrune {
+				assert.Empty(t, objs)
+				return
+			}
+			require.Len(t, objs, 1)
+			assert.Equal(t, wellknown.NetworkPolicyGVK, objs[0].GetObjectKind().GroupVersionKind())
+			assert.Equal(t, "foo", objs[0].GetName())
+			assert.Equal(t, defaultNamespace, objs[0].GetNamespace())
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"regexp"
 	"strconv"
 	"unicode/utf8"
 
@@ -30,7 +31,7 @@ import (
 )
 
 const (
-	// lambdaServiceName is the service name for the lambda filter.
+	// lambdaServiceName is the default service name used to sign requests for the lambda filter.
 	lambdaServiceName = "lambda"
 	// lambdaFilterName is the name of the lambda filter.
 	lambdaFilterName = "envoy.filters.http.aws_lambda"
@@ -40,6 +41,14 @@ const (
 	upstreamCodecFilterName = "envoy.filters.http.upstream_codec"
 )
 
+// ErrInvalidAWSRegion is returned when an AWS backend references a region that is not a
+// well-formed AWS region name.
+var ErrInvalidAWSRegion = errors.New("invalid AWS region")
+
+// awsRegionRegex matches AWS region names across partitions, e.g. us-east-1, us-gov-west-1,
+// cn-north-1 or us-isob-east-1.
+var awsRegionRegex = regexp.MustCompile(`^[a-z]{2}(-[a-z]+)+-[0-9]$`)
+
 // AwsIr is the internal representation of an AWS backend.
 type AwsIr struct {
 	lambdaFilters         *lambdaFilters
@@ -126,13 +135,16 @@ func processAws(ir *AwsIr, out *envoyclusterv3.Cluster) error {
 	return nil
 }
 
-// configureAWSAuth configures AWS authentication for the given backend.
-func configureAWSAuth(secret *ir.Secret, region string) (*envoy_request_signing_v3.AwsRequestSigning, error) {
+// configureAWSAuth configures AWS authentication for the given backend, signing requests for the given service.
+func configureAWSAuth(secret *ir.Secret, region, serviceName string) (*envoy_request_signing_v3.AwsRequestSigning, error) {
+	if !awsRegionRegex.MatchString(region) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidAWSRegion, region)
+	}
 	// when no auth is specified, use the default aws auth provider documented by the lambda filter:
 	// https://www.envoyproxy.io/docs/envoy/latest/configuration/http/http_filters/aws_lambda_filter#credentials.
 	if secret == nil || secret.Data == nil {
 		return &envoy_request_signing_v3.AwsRequestSigning{
-			ServiceName: lambdaServiceName,
+			ServiceName: serviceName,
 			Region:      region,
 		}, nil
 	}
@@ -143,7 +155,7 @@ func configureAWSAuth(secret *ir.Secret, region string) (*envoy_request_signing_
 	}
 
 	return &envoy_request_signing_v3.AwsRequestSigning{
-		ServiceName: lambdaServiceName,
+		ServiceName: serviceName,
 		Region:      region,
 		CredentialProvider: &envoy_aws_common_v3.AwsCredentialProvider{
 			InlineCredential: &envoy_aws_common_v3.InlineCredentialProvider{
@@ -178,6 +190,7 @@ func (u *lambdaFilters) Equals(other *lambdaFilters) bool {
 func buildLambdaFilters(
 	arn string,
 	region string,
+	serviceName string,
 	secret *ir.Secret,
 	invokeMode envoy_lambda_v3.Config_InvocationMode,
 	payloadTransformMode kgateway.AWSLambdaPayloadTransformMode,
@@ -199,13 +212,13 @@ func buildLambdaFilters(
 		return nil, fmt.Errorf("failed to create lambda config: %v", err)
 	}
 
-	awsRequestSigning, err := configureAWSAuth(secret, region)
+	awsRequestSigning, err := configureAWSAuth(secret, region, serviceName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create aws request signing config: %v", err)
+		return nil, fmt.Errorf("failed to create aws request signing config: %w", err)
 	}
 	awsRequestSigningAny, err := utils.MessageToAny(awsRequestSigning)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create aws request signing config: %v", err)
+		return nil, fmt.Errorf("failed to create aws request signing config: %w", err)
 	}
 
 	codecConfigAny, err := utils.MessageToAny(&envoy_upstream_codec.UpstreamCodec{})
@@ -229,6 +242,15 @@ func getLambdaHostname(in *kgateway.AwsBackend) string {
 	return fmt.Sprintf("lambda.%s.amazonaws.com", in.Region)
 }
 
+// getSigningServiceName returns the service name used to sign requests to the backend. When a service name
+// has been specified, it will be returned. Otherwise, the lambda service name is returned.
+func getSigningServiceName(in *kgateway.AwsBackend) string {
+	if in.ServiceName != nil {
+		return *in.ServiceName
+	}
+	return lambdaServiceName
+}
+
 // getLambdaInvocationMode returns the Lambda invocation mode. Default is synchronous.
 func getLambdaInvocationMode(in *kgateway.AwsBackend) envoy_lambda_v3.Config_InvocationMode {
 	invokeMode := envoy_lambda_v3.Config_SYNCHRONOUS
@@ -0,0 +1,228 @@
+package backend
+
+import (
+	"testing"
+
+	envoy_aws_common_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/common/aws/v3"
+	envoy_request_signing_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/aws_request_signing/v3"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/testing/protocmp"
+	"istio.io/istio/pkg/kube/krt"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/extensions2/pluginutils"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+func TestConfigureAWSAuth(t *testing.T) {
+	secret := &ir.Secret{
+		Data: map[string][]byte{
+			wellknown.AccessKey: []byte("access"),
+			wellknown.SecretKey: []byte("secret"),
+		},
+	}
+
+	tests := []struct {
+		name        string
+		secret      *ir.Secret
+		serviceName string
+		want        *envoy_request_signing_v3.AwsRequestSigning
+	}{
+		{
+			name: "default credential chain",
+			want: &envoy_request_signing_v3.AwsRequestSigning{
+				ServiceName: "lambda",
+				Region:      "us-west-2",
+			},
+		},
+		{
+			name:   "inline credentials",
+			secret: secret,
+			want: &envoy_request_signing_v3.AwsRequestSigning{
+				ServiceName: "lambda",
+				Region:      "us-west-2",
+				CredentialProvider: &envoy_aws_common_v3.AwsCredentialProvider{
+					InlineCredential: &envoy_aws_common_v3.InlineCredentialProvider{
+						AccessKeyId:     "access",
+						SecretAccessKey: "secret",
+					},
+				},
+			},
+		},
+		{
+			name: "temporary credentials with session token",
+			secret: &ir.Secret{
+				Data: map[string][]byte{
+					wellknown.AccessKey:    []byte("access"),
+					wellknown.SecretKey:    []byte("secret"),
+					wellknown.SessionToken: []byte("session"),
+				},
+			},
+			want: &envoy_request_signing_v3.AwsRequestSigning{
+				ServiceName: "lambda",
+				Region:      "us-west-2",
+				CredentialProvider: &envoy_aws_common_v3.AwsCredentialProvider{
+					InlineCredential: &envoy_aws_common_v3.InlineCredentialProvider{
+						AccessKeyId:     "access",
+						SecretAccessKey: "secret",
+						SessionToken:    "session",
+					},
+				},
+			},
+		},
+		{
+			name:        "custom service name",
+			secret:      secret,
+			serviceName: "execute-api",
+			want: &envoy_request_signing_v3.AwsRequestSigning{
+				ServiceName: "execute-api",
+				Region:      "us-west-2",
+				CredentialProvider: &envoy_aws_common_v3.AwsCredentialProvider{
+					InlineCredential: &envoy_aws_common_v3.InlineCredentialProvider{
+						AccessKeyId:     "access",
+						SecretAccessKey: "secret",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serviceName := tt.serviceName
+			if serviceName == "" {
+				serviceName = lambdaServiceName
+			}
+			got, err := configureAWSAuth(tt.secret, "us-west-2", serviceName)
+			require.NoError(t, err)
+			assert.Empty(t, cmp.Diff(tt.want, got, protocmp.Transform()))
+		})
+	}
+}
+
+func TestConfigureAWSAuthInvalidSecret(t *testing.T) {
+	_, err := configureAWSAuth(&ir.Secret{
+		Data: map[string][]byte{
+			wellknown.AccessKey:    []byte("access"),
+			wellknown.SecretKey:    []byte("secret"),
+			wellknown.SessionToken: {0xff},
+		},
+	}, "us-west-2", lambdaServiceName)
+	require.ErrorContains(t, err, "session_key is not a valid string")
+}
+
+func TestConfigureAWSAuthRegion(t *testing.T) {
+	tests := []struct {
+		region  string
+		wantErr bool
+	}{
+		{region: "us-west-2"},
+		{region: "eu-central-1"},
+		{region: "ap-southeast-3"},
+		{region: "us-gov-west-1"},
+		{region: "cn-north-1"},
+		{region: "cn-northwest-1"},
+		{region: "us-isob-east-1"},
+		{region: "", wantErr: true},
+		{region: "us-west", wantErr: true},
+		{region: "us-west-22", wantErr: true},
+		{region: "US-WEST-2", wantErr: true},
+		{region: "uswest2", wantErr: true},
+		{region: "us-west-2-", wantErr: true},
+		{region: "us--west-2", wantErr: true},
+		{region: "r1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.region, func(t *testing.T) {
+			got, err := configureAWSAuth(nil, tt.region, lambdaServiceName)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidAWSRegion)
+				assert.Nil(t, got)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.region, got.GetRegion())
+		})
+	}
+}
+
+func TestTranslateBackendSigningServiceName(t *testing.T) {
+	tests := []struct {
+		name        string
+		serviceName *string
+		want        string
+	}{
+		{
+			name: "defaults to lambda",
+			want: "lambda",
+		},
+		{
+			name:        "custom service name",
+			serviceName: ptr.To("execute-api"),
+			want:        "execute-api",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := &kgateway.Backend{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "lambda",
+					Namespace: "default",
+				},
+				Spec: kgateway.BackendSpec{
+					Aws: &kgateway.AwsBackend{
+						AccountId:   "123456789012",
+						Region:      "us-west-2",
+						ServiceName: tt.serviceName,
+						Lambda: kgateway.AwsLambda{
+							FunctionName: "my-function",
+						},
+					},
+				},
+			}
+
+			beIr := buildTranslateFunc(nil)(krt.TestingDummyContext{}, backend)
+			require.Empty(t, beIr.errors)
+			require.NotNil(t, beIr.awsIr)
+
+			awsRequestSigning := &envoy_request_signing_v3.AwsRequestSigning{}
+			require.NoError(t, beIr.awsIr.lambdaFilters.awsRequestSigningAny.UnmarshalTo(awsRequestSigning))
+			assert.Equal(t, tt.want, awsRequestSigning.GetServiceName())
+			assert.Equal(t, "us-west-2", awsRequestSigning.GetRegion())
+		})
+	}
+}
+
+func TestTranslateBackendInvalidRegion(t *testing.T) {
+	backend := &kgateway.Backend{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "lambda",
+			Namespace: "default",
+		},
+		Spec: kgateway.BackendSpec{
+			Aws: &kgateway.AwsBackend{
+				AccountId: "123456789012",
+				Region:    "us-west",
+				Lambda: kgateway.AwsLambda{
+					FunctionName: "my-function",
+				},
+			},
+		},
+	}
+
+	beIr := buildTranslateFunc(nil)(krt.TestingDummyContext{}, backend)
+	require.Len(t, beIr.errors, 1)
+	assert.ErrorIs(t, beIr.errors[0], ErrInvalidAWSRegion)
+
+	condition := pluginutils.BuildCondition("Backend", beIr.errors)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, "Invalid", condition.Reason)
+	assert.Contains(t, condition.Message, `invalid AWS region: "us-west"`)
+}
@@ -140,6 +140,7 @@ func buildTranslateFunc(
 		case i.Spec.Aws != nil:
 			region := i.Spec.Aws.Region
 			invokeMode := getLambdaInvocationMode(i.Spec.Aws)
+			serviceName := getSigningServiceName(i.Spec.Aws)
 
 			lambdaArn, err := buildLambdaARN(i.Spec.Aws, region)
 			if err != nil {
@@ -178,7 +179,7 @@ func buildTranslateFunc(
 			}
 
 			lambdaFilters, err := buildLambdaFilters(
-				lambdaArn, region, secret, invokeMode, i.Spec.Aws.Lambda.PayloadTransformMode)
+				lambdaArn, region, serviceName, secret, invokeMode, i.Spec.Aws.Lambda.PayloadTransformMode)
 			if err != nil {
 				beIr.errors = append(beIr.errors, err)
 			}
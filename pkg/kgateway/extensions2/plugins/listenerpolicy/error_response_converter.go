@@ -0,0 +1,119 @@
+package listenerpolicy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	envoyaccesslogv3 "github.com/envoyproxy/go-control-plane/envoy/config/accesslog/v3"
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoy_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"k8s.io/utils/ptr"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+)
+
+// errorResponseTemplateData renders the placeholders of an error response body template to
+// the Envoy command operators that are substituted when the local reply is sent
+var errorResponseTemplateData = struct {
+	StatusCode string
+	Message    string
+}{
+	StatusCode: "%RESPONSE_CODE%",
+	Message:    "%LOCAL_REPLY_BODY%",
+}
+
+func convertErrorResponses(policy *kgateway.HTTPSettings) (*envoy_hcm.LocalReplyConfig, error) {
+	if len(policy.ErrorResponses) == 0 {
+		return nil, nil
+	}
+
+	var errs []error
+	mappers := make([]*envoy_hcm.ResponseMapper, 0, len(policy.ErrorResponses))
+	for _, errorResponse := range policy.ErrorResponses {
+		body, err := renderErrorResponseBody(errorResponse.Body)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid error response body for status code %d: %w", errorResponse.StatusCode, err))
+			continue
+		}
+
+		mappers = append(mappers, &envoy_hcm.ResponseMapper{
+			Filter: &envoyaccesslogv3.AccessLogFilter{
+				FilterSpecifier: &envoyaccesslogv3.AccessLogFilter_StatusCodeFilter{
+					StatusCodeFilter: &envoyaccesslogv3.StatusCodeFilter{
+						Comparison: &envoyaccesslogv3.ComparisonFilter{
+							Op: envoyaccesslogv3.ComparisonFilter_EQ,
+							Value: &envoycorev3.RuntimeUInt32{
+								DefaultValue: uint32(errorResponse.StatusCode), // nolint:gosec // G115: kubebuilder validation ensures safe for uint32
+							},
+						},
+					},
+				},
+			},
+			BodyFormatOverride: &envoycorev3.SubstitutionFormatString{
+				Format: &envoycorev3.SubstitutionFormatString_TextFormatSource{
+					TextFormatSource: &envoycorev3.DataSource{
+						Specifier: &envoycorev3.DataSource_InlineString{
+							InlineString: body,
+						},
+					},
+				},
+				ContentType: ptr.Deref(errorResponse.ContentType, ""),
+			},
+		})
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return &envoy_hcm.LocalReplyConfig{
+		Mappers: mappers,
+	}, nil
+}
+
+// renderErrorResponseBody renders an error response body template into an Envoy format string
+func renderErrorResponseBody(body string) (string, error) {
+	tmpl, err := template.New("body").Parse(body)
+	if err != nil {
+		return "", err
+	}
+	// the rendered body is an Envoy format string, so a literal % must be escaped as %% to not be
+	// parsed as the start of a command operator. Only the literal text of the template is escaped,
+	// as the placeholders render to command operators.
+	for _, t := range tmpl.Templates() {
+		escapeFormatText(t.Root)
+	}
+
+	var out strings.Builder
+	if err := tmpl.Execute(&out, errorResponseTemplateData); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+// escapeFormatText escapes % in the text nodes of a parsed template, including the ones nested in actions
+func escapeFormatText(node parse.Node) {
+	switch n := node.(type) {
+	case *parse.TextNode:
+		n.Text = bytes.ReplaceAll(n.Text, []byte("%"), []byte("%%"))
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			escapeFormatText(child)
+		}
+	case *parse.IfNode:
+		escapeFormatText(n.List)
+		escapeFormatText(n.ElseList)
+	case *parse.RangeNode:
+		escapeFormatText(n.List)
+		escapeFormatText(n.ElseList)
+	case *parse.WithNode:
+		escapeFormatText(n.List)
+		escapeFormatText(n.ElseList)
+	}
+}
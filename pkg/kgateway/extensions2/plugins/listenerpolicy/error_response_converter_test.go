@@ -0,0 +1,120 @@
+package listenerpolicy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+)
+
+func TestRenderErrorResponseBody(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		expected  string
+		expectErr string
+	}{
+		{
+			name:     "404 JSON body",
+			body:     `{"code": {{.StatusCode}}, "message": "{{.Message}}"}`,
+			expected: `{"code": %RESPONSE_CODE%, "message": "%LOCAL_REPLY_BODY%"}`,
+		},
+		{
+			name:     "429 text body",
+			body:     "Too many requests ({{.StatusCode}}): {{.Message}}",
+			expected: "Too many requests (%RESPONSE_CODE%): %LOCAL_REPLY_BODY%",
+		},
+		{
+			name:     "body without placeholders",
+			body:     "not found",
+			expected: "not found",
+		},
+		{
+			name:     "literal percent",
+			body:     "100% not found",
+			expected: "100%% not found",
+		},
+		{
+			name:     "literal percent next to placeholders and in actions",
+			body:     "{{.StatusCode}}% {{with .Message}}({{.}} 100%){{end}}",
+			expected: "%RESPONSE_CODE%%% (%LOCAL_REPLY_BODY% 100%%)",
+		},
+		{
+			name:      "invalid template",
+			body:      "{{.StatusCode",
+			expectErr: "unclosed action",
+		},
+		{
+			name:      "unknown placeholder",
+			body:      "{{.Path}}",
+			expectErr: "can't evaluate field Path",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := renderErrorResponseBody(tt.body)
+			if tt.expectErr != "" {
+				require.ErrorContains(t, err, tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestConvertErrorResponses(t *testing.T) {
+	t.Run("no error responses", func(t *testing.T) {
+		cfg, err := convertErrorResponses(&kgateway.HTTPSettings{})
+		require.NoError(t, err)
+		require.Nil(t, cfg)
+	})
+
+	t.Run("maps each status code to its body", func(t *testing.T) {
+		cfg, err := convertErrorResponses(&kgateway.HTTPSettings{
+			ErrorResponses: []kgateway.ErrorResponse{
+				{StatusCode: 404, Body: `{"message": "{{.Message}}"}`, ContentType: ptr.To("application/json")},
+				{StatusCode: 429, Body: "slow down"},
+			},
+		})
+		require.NoError(t, err)
+		require.NoError(t, cfg.Validate())
+		require.Len(t, cfg.GetMappers(), 2)
+
+		notFound := cfg.GetMappers()[0]
+		require.Equal(t, uint32(404), notFound.GetFilter().GetStatusCodeFilter().GetComparison().GetValue().GetDefaultValue())
+		require.Equal(t, `{"message": "%LOCAL_REPLY_BODY%"}`, notFound.GetBodyFormatOverride().GetTextFormatSource().GetInlineString())
+		require.Equal(t, "application/json", notFound.GetBodyFormatOverride().GetContentType())
+
+		rateLimited := cfg.GetMappers()[1]
+		require.Equal(t, uint32(429), rateLimited.GetFilter().GetStatusCodeFilter().GetComparison().GetValue().GetDefaultValue())
+		require.Equal(t, "slow down", rateLimited.GetBodyFormatOverride().GetTextFormatSource().GetInlineString())
+		require.Empty(t, rateLimited.GetBodyFormatOverride().GetContentType())
+	})
+
+	t.Run("escapes a literal percent", func(t *testing.T) {
+		cfg, err := convertErrorResponses(&kgateway.HTTPSettings{
+			ErrorResponses: []kgateway.ErrorResponse{
+				{StatusCode: 503, Body: "100% unavailable: {{.Message}}"},
+			},
+		})
+		require.NoError(t, err)
+		require.NoError(t, cfg.Validate())
+		require.Len(t, cfg.GetMappers(), 1)
+		require.Equal(t, "100%% unavailable: %LOCAL_REPLY_BODY%", cfg.GetMappers()[0].GetBodyFormatOverride().GetTextFormatSource().GetInlineString())
+	})
+
+	t.Run("reports the status code of an invalid body", func(t *testing.T) {
+		cfg, err := convertErrorResponses(&kgateway.HTTPSettings{
+			ErrorResponses: []kgateway.ErrorResponse{
+				{StatusCode: 404, Body: "not found"},
+				{StatusCode: 503, Body: "{{.Nope}}"},
+			},
+		})
+		require.ErrorContains(t, err, "invalid error response body for status code 503")
+		require.Nil(t, cfg)
+	})
+}
@@ -55,6 +55,7 @@ type HttpListenerPolicyIr struct {
 	earlyHeaderMutationExtensions []*envoycorev3.TypedExtensionConfig
 	maxRequestHeadersKb           *uint32
 	uuidRequestIdConfig           *envoyuuidv3.UuidRequestIdConfig
+	localReplyConfig              *envoy_hcm.LocalReplyConfig
 }
 
 func (d *HttpListenerPolicyIr) Equals(in any) bool {
@@ -165,6 +166,10 @@ func (d *HttpListenerPolicyIr) Equals(in any) bool {
 		return false
 	}
 
+	if !proto.Equal(d.localReplyConfig, d2.localReplyConfig) {
+		return false
+	}
+
 	return true
 }
 
@@ -185,6 +190,12 @@ func NewHttpListenerPolicy(krtctx krt.HandlerContext, commoncol *collections.Com
 		errs = append(errs, err)
 	}
 
+	localReplyConfig, err := convertErrorResponses(h)
+	if err != nil {
+		logger.Error("error translating error responses", "error", err)
+		errs = append(errs, err)
+	}
+
 	upgradeConfigs := convertUpgradeConfig(h)
 	serverHeaderTransformation := convertServerHeaderTransformation(h.ServerHeaderTransformation)
 
@@ -240,6 +251,7 @@ func NewHttpListenerPolicy(krtctx krt.HandlerContext, commoncol *collections.Com
 		earlyHeaderMutationExtensions: convertHeaderMutations(h.EarlyRequestHeaderModifier),
 		maxRequestHeadersKb:           maxRequestHeadersKb,
 		uuidRequestIdConfig:           uuidRequestIdConfig,
+		localReplyConfig:              localReplyConfig,
 	}, errs
 }
 
@@ -445,6 +445,11 @@ func (p *listenerPolicyPluginGwPass) ApplyHCM(
 		}
 	}
 
+	// translate errorResponses
+	if policy.localReplyConfig != nil {
+		out.LocalReplyConfig = policy.localReplyConfig
+	}
+
 	return nil
 }
 
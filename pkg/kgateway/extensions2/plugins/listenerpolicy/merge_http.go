@@ -38,6 +38,7 @@ func MergeHttpPolicies(
 		mergeEarlyHeaderMutation,
 		mergeMaxRequestHeadersKb,
 		mergeUuidRequestIdConfig,
+		mergeLocalReplyConfig,
 	}
 	for _, mergeFunc := range mergeFuncs {
 		mergeFunc(origin, p1, p2, p2Ref, p2MergeOrigins, mergeOpts, mergeOrigins)
@@ -324,3 +325,19 @@ func mergeUuidRequestIdConfig(
 	p1.uuidRequestIdConfig = p2.uuidRequestIdConfig
 	mergeOrigins.SetOne(origin+"uuidRequestIdConfig", p2Ref, p2MergeOrigins)
 }
+
+func mergeLocalReplyConfig(
+	origin string,
+	p1, p2 *HttpListenerPolicyIr,
+	p2Ref *ir.AttachedPolicyRef,
+	p2MergeOrigins ir.MergeOrigins,
+	opts policy.MergeOptions,
+	mergeOrigins ir.MergeOrigins,
+) {
+	if !policy.IsMergeable(p1.localReplyConfig, p2.localReplyConfig, opts) {
+		return
+	}
+
+	p1.localReplyConfig = p2.localReplyConfig
+	mergeOrigins.SetOne(origin+"localReplyConfig", p2Ref, p2MergeOrigins)
+}
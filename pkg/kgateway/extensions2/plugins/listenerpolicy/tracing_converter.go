@@ -234,6 +234,17 @@ func convertOTelTracingConfig(
 				Name:        "envoy.tracers.opentelemetry.samplers.always_on",
 				TypedConfig: alwaysOnSampler,
 			}
+		} else if config.Sampler.TraceIDRatioBased != nil {
+			ratioSampler, _ := utils.MessageToAny(&samplersv3.TraceIdRatioBasedSamplerConfig{
+				SamplingPercentage: &typev3.FractionalPercent{
+					Numerator:   uint32(config.Sampler.TraceIDRatioBased.SamplingPercentage), // nolint:gosec // G115: kubebuilder validation ensures 0-100
+					Denominator: typev3.FractionalPercent_HUNDRED,
+				},
+			})
+			tracingCfg.Sampler = &envoycorev3.TypedExtensionConfig{
+				Name:        "envoy.tracers.opentelemetry.samplers.trace_id_ratio_based",
+				TypedConfig: ratioSampler,
+			}
 		}
 	}
 
@@ -137,6 +137,53 @@ func TestTracingConverter(t *testing.T) {
 					CustomTags: []*tracingv3.CustomTag{},
 				},
 			},
+			{
+				name: "OTel Tracing with trace ID ratio based sampler",
+				config: &kgateway.Tracing{
+					Provider: kgateway.TracingProvider{
+						OpenTelemetry: &kgateway.OpenTelemetryTracingConfig{
+							GrpcService: kgateway.CommonGrpcService{
+								BackendRef: gwv1.BackendRef{
+									BackendObjectReference: gwv1.BackendObjectReference{
+										Name: "test-service",
+									},
+								},
+							},
+							Sampler: &kgateway.Sampler{
+								TraceIDRatioBased: &kgateway.TraceIDRatioBasedConfig{
+									SamplingPercentage: 25,
+								},
+							},
+						},
+					},
+				},
+				expected: &envoy_hcm.HttpConnectionManager_Tracing{
+					Provider: &envoytracev3.Tracing_Http{
+						Name: "envoy.tracers.opentelemetry",
+						ConfigType: &envoytracev3.Tracing_Http_TypedConfig{
+							TypedConfig: mustMessageToAny(t, &envoytracev3.OpenTelemetryConfig{
+								GrpcService: &envoycorev3.GrpcService{
+									TargetSpecifier: &envoycorev3.GrpcService_EnvoyGrpc_{
+										EnvoyGrpc: &envoycorev3.GrpcService_EnvoyGrpc{
+											ClusterName: "backend_default_test-service_0",
+										},
+									},
+								},
+								ServiceName: "gw.default",
+								Sampler: &envoycorev3.TypedExtensionConfig{
+									Name: "envoy.tracers.opentelemetry.samplers.trace_id_ratio_based",
+									TypedConfig: mustMessageToAny(t, &samplersv3.TraceIdRatioBasedSamplerConfig{
+										SamplingPercentage: &typev3.FractionalPercent{
+											Numerator:   25,
+											Denominator: typev3.FractionalPercent_HUNDRED,
+										},
+									}),
+								},
+							}),
+						},
+					},
+				},
+			},
 			{
 				name: "OTel Tracing full config",
 				config: &kgateway.Tracing{
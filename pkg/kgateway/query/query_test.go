@@ -285,6 +285,74 @@ var _ = Describe("Query", func() {
 			Expect(routes.GetListenerResult(gwWithListener, "foo").Routes).To(BeEmpty())
 		})
 
+		Context("with a parentRef sectionName", func() {
+			// sectionNameGatewayAndRoute returns a gateway with two listeners and an HTTPRoute which
+			// references it with the provided sectionName
+			sectionNameGatewayAndRoute := func(sectionName string) (*gwv1.Gateway, *gwv1.HTTPRoute) {
+				gwWithListener := gw()
+				gwWithListener.Spec.Listeners = []gwv1.Listener{
+					{
+						Name:     "foo",
+						Protocol: gwv1.HTTPProtocolType,
+						Port:     80,
+					},
+					{
+						Name:     "bar",
+						Protocol: gwv1.HTTPProtocolType,
+						Port:     81,
+					},
+				}
+				hr := httpRoute()
+				ref := gwv1.ParentReference{
+					Name: gwv1.ObjectName(gwWithListener.Name),
+				}
+				if sectionName != "" {
+					ref.SectionName = ptr.To(gwv1.SectionName(sectionName))
+				}
+				hr.Spec.ParentRefs = append(hr.Spec.ParentRefs, ref)
+				return gwWithListener, hr
+			}
+
+			It("should only attach to the named listener", func() {
+				gwWithListener, hr := sectionNameGatewayAndRoute("bar")
+
+				gq := newQueries(GinkgoT(), hr)
+				routes, err := gq.GetRoutesForGateway(krt.TestingDummyContext{}, context.Background(), &ir.Gateway{Obj: gwWithListener})
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(routes.RouteErrors).To(BeEmpty())
+				Expect(routes.GetListenerResult(gwWithListener, "bar").Routes).To(HaveLen(1))
+				Expect(routes.GetListenerResult(gwWithListener, "foo").Routes).To(BeEmpty())
+			})
+
+			It("should error when no listener has the section name", func() {
+				gwWithListener, hr := sectionNameGatewayAndRoute("baz")
+
+				gq := newQueries(GinkgoT(), hr)
+				routes, err := gq.GetRoutesForGateway(krt.TestingDummyContext{}, context.Background(), &ir.Gateway{Obj: gwWithListener})
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(routes.RouteErrors).To(HaveLen(1))
+				Expect(routes.RouteErrors[0].Error.E).To(MatchError(query.ErrNoMatchingParent))
+				Expect(routes.RouteErrors[0].Error.Reason).To(Equal(gwv1.RouteReasonNoMatchingParent))
+				Expect(routes.RouteErrors[0].ParentRef).To(Equal(hr.Spec.ParentRefs[0]))
+				Expect(routes.GetListenerResult(gwWithListener, "foo").Routes).To(BeEmpty())
+				Expect(routes.GetListenerResult(gwWithListener, "bar").Routes).To(BeEmpty())
+			})
+
+			It("should attach to all listeners without a section name", func() {
+				gwWithListener, hr := sectionNameGatewayAndRoute("")
+
+				gq := newQueries(GinkgoT(), hr)
+				routes, err := gq.GetRoutesForGateway(krt.TestingDummyContext{}, context.Background(), &ir.Gateway{Obj: gwWithListener})
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(routes.RouteErrors).To(BeEmpty())
+				Expect(routes.GetListenerResult(gwWithListener, "foo").Routes).To(HaveLen(1))
+				Expect(routes.GetListenerResult(gwWithListener, "bar").Routes).To(HaveLen(1))
+			})
+		})
+
 		It("should error when listeners hostnames don't intersect", func() {
 			gwWithListener := gw()
 			var hostname gwv1.Hostname = "foo.com"
@@ -0,0 +1,186 @@
+package setup_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"istio.io/istio/pkg/test/util/retry"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/config"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+
+	apisettings "github.com/kgateway-dev/kgateway/v2/api/settings"
+	agwplugins "github.com/kgateway-dev/kgateway/v2/pkg/agentgateway/plugins"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/setup"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/collections"
+	"github.com/kgateway-dev/kgateway/v2/test/envtestutil"
+)
+
+const (
+	leaderElectionTestID = "kgateway-leader-election-test"
+	leaseDuration        = 4 * time.Second
+)
+
+// TestLeaderElection runs two controllers against the same API server, and checks that only the controller holding
+// the lease runs the runnables which need leader election, such as the Gateway reconciler and the status syncers,
+// and that the other controller takes over once the leader stops.
+func TestLeaderElection(t *testing.T) {
+	st, err := envtestutil.BuildSettings()
+	if err != nil {
+		t.Fatalf("can't get settings %v", err)
+	}
+	st.DisableLeaderElection = false
+
+	testEnv := &envtest.Environment{
+		CRDDirectoryPaths: []string{
+			filepath.Join("..", "crds"),
+			filepath.Join("..", "..", "..", "install", "helm", "kgateway-crds", "templates"),
+			filepath.Join("..", "..", "..", "install", "helm", "agentgateway-crds", "templates"),
+			filepath.Join("testdata", "istio_crds_setup"),
+		},
+		ErrorIfCRDPathMissing: true,
+		// set assets dir so we can run without the makefile
+		BinaryAssetsDirectory: getAssetsDir(t),
+		// This often hangs (for unknown reasons); we don't need cleanup so just kill it almost instantly
+		ControlPlaneStopTimeout: time.Millisecond,
+	}
+	cfg, err := testEnv.Start()
+	if err != nil {
+		t.Fatalf("failed to start envtest: %v", err)
+	}
+	t.Cleanup(func() { testEnv.Stop() })
+
+	first := startLeaderElectedController(t, cfg, st)
+	second := startLeaderElectedController(t, cfg, st)
+
+	var leader, follower *leaderElectedController
+	retry.UntilSuccessOrFail(t, func() error {
+		switch {
+		case first.isLeader() && !second.isLeader():
+			leader, follower = first, second
+		case second.isLeader() && !first.isLeader():
+			leader, follower = second, first
+		default:
+			return fmt.Errorf("expected exactly one leader, first: %v, second: %v", first.isLeader(), second.isLeader())
+		}
+		return nil
+	}, retry.Timeout(30*time.Second), retry.Delay(500*time.Millisecond))
+
+	// the follower must not run the leader runnables while the leader keeps renewing its lease
+	deadline := time.Now().Add(2 * leaseDuration)
+	for time.Now().Before(deadline) {
+		if follower.isLeader() {
+			t.Fatal("expected the follower not to run the leader runnables while the leader holds the lease")
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+
+	leader.stop()
+	retry.UntilSuccessOrFail(t, func() error {
+		if !follower.isLeader() {
+			return fmt.Errorf("expected the follower to take over once the leader stopped")
+		}
+		return nil
+	}, retry.Timeout(30*time.Second), retry.Delay(500*time.Millisecond))
+}
+
+// leaderElectedController is a kgateway controller which records whether its leader runnables were started.
+type leaderElectedController struct {
+	leaderRunnable *leaderRunnable
+	cancel         context.CancelFunc
+	wg             sync.WaitGroup
+}
+
+func (c *leaderElectedController) isLeader() bool {
+	return c.leaderRunnable.started.Load()
+}
+
+func (c *leaderElectedController) stop() {
+	c.cancel()
+	c.wg.Wait()
+}
+
+func startLeaderElectedController(t *testing.T, cfg *rest.Config, st *apisettings.Settings) *leaderElectedController {
+	t.Helper()
+
+	xdsListener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("can't listen %v", err)
+	}
+	agwXdsListener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("can't listen %v", err)
+	}
+
+	c := &leaderElectedController{leaderRunnable: &leaderRunnable{}}
+	s, err := setup.New(
+		setup.WithGlobalSettings(st),
+		setup.WithRestConfig(cfg),
+		setup.WithXDSListener(xdsListener),
+		setup.WithAgwXDSListener(agwXdsListener),
+		setup.WithControllerManagerOptions(func(ctx context.Context) *ctrl.Options {
+			return &ctrl.Options{
+				BaseContext:            func() context.Context { return ctx },
+				Scheme:                 runtime.NewScheme(),
+				PprofBindAddress:       "0",
+				HealthProbeBindAddress: "0",
+				Metrics:                metricsserver.Options{BindAddress: "0"},
+				Controller: config.Controller{
+					// both controllers register controllers with the same names in this process
+					SkipNameValidation: ptr.To(true),
+				},
+				LeaderElection:                !st.DisableLeaderElection,
+				LeaderElectionNamespace:       "default",
+				LeaderElectionID:              leaderElectionTestID,
+				LeaderElectionReleaseOnCancel: true,
+				LeaseDuration:                 ptr.To(leaseDuration),
+				RenewDeadline:                 ptr.To(leaseDuration / 2),
+				RetryPeriod:                   ptr.To(leaseDuration / 8),
+			}
+		}),
+		setup.WithExtraRunnables(func(context.Context, *collections.CommonCollections, *agwplugins.AgwCollections, *apisettings.Settings) (bool, manager.Runnable) {
+			return true, c.leaderRunnable
+		}),
+	)
+	if err != nil {
+		t.Fatalf("error setting up kgateway %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	c.wg.Go(func() {
+		if err := s.Start(ctx); err != nil {
+			t.Errorf("error starting kgateway %v", err)
+		}
+	})
+	t.Cleanup(c.stop)
+	return c
+}
+
+// leaderRunnable records when it is started, which the manager only does once it is elected as the leader.
+type leaderRunnable struct {
+	started atomic.Bool
+}
+
+var _ manager.LeaderElectionRunnable = (*leaderRunnable)(nil)
+
+func (r *leaderRunnable) Start(ctx context.Context) error {
+	r.started.Store(true)
+	<-ctx.Done()
+	return nil
+}
+
+func (r *leaderRunnable) NeedLeaderElection() bool {
+	return true
+}
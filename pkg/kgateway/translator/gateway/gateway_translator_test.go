@@ -150,6 +150,17 @@ func TestBasic(t *testing.T) {
 		})
 	})
 
+	t.Run("http gateway with weighted backends", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "http-routing/weighted-backends.yaml",
+			outputFile: "http-routing/weighted-backends-proxy.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
 	t.Run("https gateway with basic routing", func(t *testing.T) {
 		test(t, translatorTestCase{
 			inputFile:  "https-routing/gateway.yaml",
@@ -1418,6 +1429,17 @@ func TestBasic(t *testing.T) {
 		})
 	})
 
+	t.Run("ListenerPolicy with errorResponses", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "listener-policy-http/error-responses.yaml",
+			outputFile: "listener-policy-http/error-responses.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
 	t.Run("ListenerPolicy with uuidRequestIdConfig explicit false", func(t *testing.T) {
 		test(t, translatorTestCase{
 			inputFile:  "listener-policy-http/request-id-config-explicit.yaml",
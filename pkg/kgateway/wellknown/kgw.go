@@ -76,6 +76,9 @@ func GVKToGVR(gvk schema.GroupVersionKind) (schema.GroupVersionResource, error)
 		return AgentgatewayPolicyGVR, nil
 	case AgentgatewayBackendGVK:
 		return AgentgatewayBackendGVR, nil
+	// Kubernetes types not known to the Istio lib
+	case NetworkPolicyGVK:
+		return NetworkPolicyGVR, nil
 	default:
 		return schema.GroupVersionResource{}, fmt.Errorf("unknown GVK: %v", gvk)
 	}
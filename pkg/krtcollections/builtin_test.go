@@ -167,6 +167,104 @@ func TestURLRewriteApply(t *testing.T) {
 	}
 }
 
+func TestConvertURLRewriteIR(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   *gwv1.HTTPURLRewriteFilter
+		expected *urlRewriteIr
+	}{
+		{
+			name: "prefix rewrite without hostname",
+			filter: &gwv1.HTTPURLRewriteFilter{
+				Path: &gwv1.HTTPPathModifier{
+					Type:               gwv1.PrefixMatchHTTPPathModifier,
+					ReplacePrefixMatch: ptr.To("/new"),
+				},
+			},
+			expected: &urlRewriteIr{PrefixReplace: "/new"},
+		},
+		{
+			name: "prefix rewrite with hostname",
+			filter: &gwv1.HTTPURLRewriteFilter{
+				Hostname: ptr.To(gwv1.PreciseHostname("example.com")),
+				Path: &gwv1.HTTPPathModifier{
+					Type:               gwv1.PrefixMatchHTTPPathModifier,
+					ReplacePrefixMatch: ptr.To("/new"),
+				},
+			},
+			expected: &urlRewriteIr{
+				HostRewrite:   &envoyroutev3.RouteAction_HostRewriteLiteral{HostRewriteLiteral: "example.com"},
+				PrefixReplace: "/new",
+			},
+		},
+		{
+			name: "full path rewrite without hostname",
+			filter: &gwv1.HTTPURLRewriteFilter{
+				Path: &gwv1.HTTPPathModifier{
+					Type:            gwv1.FullPathHTTPPathModifier,
+					ReplaceFullPath: ptr.To("/new/path"),
+				},
+			},
+			expected: &urlRewriteIr{FullReplace: "/new/path"},
+		},
+		{
+			name: "full path rewrite with hostname",
+			filter: &gwv1.HTTPURLRewriteFilter{
+				Hostname: ptr.To(gwv1.PreciseHostname("example.com")),
+				Path: &gwv1.HTTPPathModifier{
+					Type:            gwv1.FullPathHTTPPathModifier,
+					ReplaceFullPath: ptr.To("/new/path"),
+				},
+			},
+			expected: &urlRewriteIr{
+				HostRewrite: &envoyroutev3.RouteAction_HostRewriteLiteral{HostRewriteLiteral: "example.com"},
+				FullReplace: "/new/path",
+			},
+		},
+		{
+			name: "prefix rewrite defaults to /",
+			filter: &gwv1.HTTPURLRewriteFilter{
+				Path: &gwv1.HTTPPathModifier{Type: gwv1.PrefixMatchHTTPPathModifier},
+			},
+			expected: &urlRewriteIr{PrefixReplace: "/"},
+		},
+		{
+			name:     "nil filter",
+			expected: nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, convertURLRewriteIR(nil, tc.filter))
+		})
+	}
+}
+
+func TestURLRewriteApplyFullPathAndHostname(t *testing.T) {
+	route := &envoyroutev3.Route{
+		Match: &envoyroutev3.RouteMatch{
+			PathSpecifier: &envoyroutev3.RouteMatch_PathSeparatedPrefix{
+				PathSeparatedPrefix: "/old/path",
+			},
+		},
+		Action: &envoyroutev3.Route_Route{Route: &envoyroutev3.RouteAction{}},
+	}
+	u := urlRewriteIr{
+		HostRewrite: &envoyroutev3.RouteAction_HostRewriteLiteral{HostRewriteLiteral: "example.com"},
+		FullReplace: "/new/path",
+	}
+
+	u.apply(route, policy.MergeOptions{Strategy: policy.AugmentedShallowMerge})
+
+	assert.Equal(t, "example.com", route.GetRoute().GetHostRewriteLiteral())
+	assert.True(t, proto.Equal(&envoy_type_matcher_v3.RegexMatchAndSubstitute{
+		Pattern:      &envoy_type_matcher_v3.RegexMatcher{Regex: ".*"},
+		Substitution: "/new/path",
+	}, route.GetRoute().GetRegexRewrite()))
+	assert.Empty(t, route.GetRoute().GetPrefixRewrite())
+}
+
 func TestParseRedirectStatusCodeAnnotation(t *testing.T) {
 	sectionName := func(s string) *gwv1.SectionName {
 		return ptr.To(gwv1.SectionName(s))
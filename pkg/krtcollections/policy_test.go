@@ -233,6 +233,63 @@ func TestFailWithWrongNs(t *testing.T) {
 	}
 }
 
+func TestFailWithRefGrantAndWrongFromNs(t *testing.T) {
+	rg := refGrant()
+	for i := range rg.Spec.From {
+		rg.Spec.From[i].Namespace = gwv1.Namespace("other")
+	}
+
+	inputs := []any{
+		svc("default2"),
+		rg,
+	}
+	for _, backend := range backends("default2") {
+		t.Run(fmt.Sprintf("backend %T", backend), func(t *testing.T) {
+			inputs := append(inputs, backend)
+			ir := translateRoute(t, inputs)
+			if ir == nil {
+				t.Fatalf("expected ir")
+			}
+			backends := getBackends(ir)
+			if backends == nil {
+				t.Fatalf("expected backends")
+			}
+			if backends[0].Err == nil {
+				t.Fatalf("expected backend error")
+			}
+			if !strings.Contains(backends[0].Err.Error(), "missing reference grant") {
+				t.Fatalf("expected missing reference grant error, found: %v", backends[0].Err)
+			}
+		})
+	}
+}
+
+func TestFailServiceWithRefGrantWrongKind(t *testing.T) {
+	rg := refGrant()
+	rg.Spec.To[0].Kind = gwv1.Kind("WrongKind")
+
+	inputs := []any{
+		svc("default2"),
+		rg,
+		httpRouteWithSvcBackendRef("default2"),
+	}
+
+	ir := translateRoute(t, inputs)
+	if ir == nil {
+		t.Fatalf("expected ir")
+	}
+	backends := getBackends(ir)
+	if backends == nil {
+		t.Fatalf("expected backends")
+	}
+	if backends[0].Err == nil {
+		t.Fatalf("expected backend error")
+	}
+	if !strings.Contains(backends[0].Err.Error(), "missing reference grant") {
+		t.Fatalf("expected missing reference grant error, found: %v", backends[0].Err)
+	}
+}
+
 func TestInferencePoolBackendSameNamespace(t *testing.T) {
 	inputs := []any{
 		infPool(""),
@@ -39,6 +39,39 @@ func TestBuildRetryPolicy(t *testing.T) {
 				RetriableStatusCodes: nil,
 			},
 		},
+		{
+			name: "retry on 5xx",
+			input: &kgateway.Retry{
+				RetryOn:  []kgateway.RetryOnCondition{"5xx"},
+				Attempts: int32(2),
+			},
+			want: &envoyroutev3.RetryPolicy{
+				RetryOn:    "5xx",
+				NumRetries: wrapperspb.UInt32(2),
+			},
+		},
+		{
+			name: "retry on gateway-error",
+			input: &kgateway.Retry{
+				RetryOn:  []kgateway.RetryOnCondition{"gateway-error"},
+				Attempts: int32(2),
+			},
+			want: &envoyroutev3.RetryPolicy{
+				RetryOn:    "gateway-error",
+				NumRetries: wrapperspb.UInt32(2),
+			},
+		},
+		{
+			name: "retry on connect-failure",
+			input: &kgateway.Retry{
+				RetryOn:  []kgateway.RetryOnCondition{"connect-failure"},
+				Attempts: int32(2),
+			},
+			want: &envoyroutev3.RetryPolicy{
+				RetryOn:    "connect-failure",
+				NumRetries: wrapperspb.UInt32(2),
+			},
+		},
 		{
 			name: "retry policy with status codes without retriable-status-codes in retryOn",
 			input: &kgateway.Retry{
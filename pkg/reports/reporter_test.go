@@ -140,6 +140,109 @@ var _ = Describe("Reporting Infrastructure", func() {
 		// TODO(Law): add test confirming transitionTime change when status change
 	})
 
+	Describe("aggregating gateway conditions", func() {
+		listenerStatus := func(name string, programmed metav1.ConditionStatus, reason gwv1.ListenerConditionReason, message string) gwv1.ListenerStatus {
+			return gwv1.ListenerStatus{
+				Name:           gwv1.SectionName(name),
+				AttachedRoutes: 1,
+				Conditions: []metav1.Condition{{
+					Type:    string(gwv1.ListenerConditionProgrammed),
+					Status:  programmed,
+					Reason:  string(reason),
+					Message: message,
+				}},
+			}
+		}
+		noRoutesListenerStatus := func(name string) gwv1.ListenerStatus {
+			lis := listenerStatus(name, metav1.ConditionFalse, gwv1.ListenerReasonInvalid, "TCP/TLS listener has no valid backends or routes")
+			lis.AttachedRoutes = 0
+			return lis
+		}
+
+		DescribeTable("should roll up listener Programmed conditions",
+			func(listeners []gwv1.ListenerStatus, status metav1.ConditionStatus, reason gwv1.GatewayConditionReason, message string) {
+				conditions := reports.AggregateGatewayConditions(listeners)
+
+				Expect(conditions).To(HaveLen(1))
+				Expect(conditions[0].Type).To(Equal(string(gwv1.GatewayConditionProgrammed)))
+				Expect(conditions[0].Status).To(Equal(status))
+				Expect(conditions[0].Reason).To(Equal(string(reason)))
+				Expect(conditions[0].Message).To(Equal(message))
+			},
+			Entry("all listeners healthy",
+				[]gwv1.ListenerStatus{
+					listenerStatus("http", metav1.ConditionTrue, gwv1.ListenerReasonProgrammed, ""),
+					listenerStatus("https", metav1.ConditionTrue, gwv1.ListenerReasonProgrammed, ""),
+				},
+				metav1.ConditionTrue, gwv1.GatewayReasonProgrammed, reports.GatewayProgrammedMessage,
+			),
+			Entry("mixed healthy and unhealthy listeners",
+				[]gwv1.ListenerStatus{
+					listenerStatus("http", metav1.ConditionTrue, gwv1.ListenerReasonProgrammed, ""),
+					listenerStatus("https", metav1.ConditionFalse, gwv1.ListenerReasonInvalid, "invalid certificate"),
+				},
+				metav1.ConditionTrue, gwv1.GatewayReasonProgrammed, "Some listeners are not programmed: https: invalid certificate",
+			),
+			Entry("all listeners unhealthy with distinct reasons",
+				[]gwv1.ListenerStatus{
+					listenerStatus("https", metav1.ConditionFalse, gwv1.ListenerReasonInvalid, "invalid certificate"),
+					listenerStatus("tcp-a", metav1.ConditionFalse, gwv1.ListenerReasonPending, "waiting for backends"),
+					listenerStatus("tcp-b", metav1.ConditionFalse, gwv1.ListenerReasonPending, ""),
+				},
+				metav1.ConditionFalse, gwv1.GatewayReasonInvalid, "No listeners are programmed: https: invalid certificate; tcp-a: waiting for backends; tcp-b: Pending",
+			),
+			Entry("all listeners pending",
+				[]gwv1.ListenerStatus{
+					listenerStatus("tcp-a", metav1.ConditionFalse, gwv1.ListenerReasonPending, "waiting for backends"),
+					listenerStatus("tcp-b", metav1.ConditionFalse, gwv1.ListenerReasonPending, "waiting for backends"),
+				},
+				metav1.ConditionFalse, gwv1.GatewayReasonPending, "No listeners are programmed: tcp-a: waiting for backends; tcp-b: waiting for backends",
+			),
+			Entry("all listeners waiting for routes",
+				[]gwv1.ListenerStatus{
+					noRoutesListenerStatus("tcp-a"),
+					noRoutesListenerStatus("tcp-b"),
+				},
+				metav1.ConditionTrue, gwv1.GatewayReasonProgrammed,
+				"Some listeners are not programmed: tcp-a: TCP/TLS listener has no valid backends or routes; tcp-b: TCP/TLS listener has no valid backends or routes",
+			),
+			Entry("all listeners unhealthy, some waiting for routes",
+				[]gwv1.ListenerStatus{
+					listenerStatus("https", metav1.ConditionFalse, gwv1.ListenerReasonInvalid, "invalid certificate"),
+					noRoutesListenerStatus("tcp"),
+				},
+				metav1.ConditionFalse, gwv1.GatewayReasonInvalid,
+				"No listeners are programmed: https: invalid certificate; tcp: TCP/TLS listener has no valid backends or routes",
+			),
+		)
+
+		It("should set the aggregated condition when building gateway status", func() {
+			gw := gw()
+			rm := reports.NewReportMap()
+			r := reports.NewReporter(&rm)
+			lisReport := r.Gateway(gw).Listener(listener())
+			lisReport.SetCondition(reporter.ListenerCondition{
+				Type:    gwv1.ListenerConditionResolvedRefs,
+				Status:  metav1.ConditionFalse,
+				Reason:  gwv1.ListenerReasonInvalidCertificateRef,
+				Message: "secret not found",
+			})
+			lisReport.SetCondition(reporter.ListenerCondition{
+				Type:    gwv1.ListenerConditionProgrammed,
+				Status:  metav1.ConditionFalse,
+				Reason:  gwv1.ListenerReasonInvalid,
+				Message: "invalid certificate",
+			})
+			status := rm.BuildGWStatus(context.Background(), *gw, nil)
+
+			Expect(status).NotTo(BeNil())
+			programmed := meta.FindStatusCondition(status.Conditions, string(gwv1.GatewayConditionProgrammed))
+			Expect(programmed.Status).To(Equal(metav1.ConditionFalse))
+			Expect(programmed.Reason).To(Equal(string(gwv1.GatewayReasonInvalid)))
+			Expect(programmed.Message).To(Equal("No listeners are programmed: http: invalid certificate"))
+		})
+	})
+
 	Describe("building route status", func() {
 		DescribeTable("should build all positive route conditions with an empty report",
 			func(obj client.Object) {
@@ -103,6 +103,20 @@ func (r *ReportMap) BuildGWStatus(ctx context.Context, gw gwv1.Gateway, attached
 
 	handleInvalidAddresses(gwReport, &gw)
 
+	// Roll up the Programmed conditions of invalid listeners, unless the Gateway itself has already been reported as not programmed.
+	// Otherwise, a healthy Programmed condition is added along with the other missing conditions below.
+	programmed := meta.FindStatusCondition(gwReport.GetConditions(), string(gwv1.GatewayConditionProgrammed))
+	if len(invalidListeners) > 0 && (programmed == nil || programmed.Status == metav1.ConditionTrue) {
+		for _, condition := range AggregateGatewayConditions(finalListeners) {
+			gwReport.SetCondition(reporter.GatewayCondition{
+				Type:    gwv1.GatewayConditionType(condition.Type),
+				Status:  condition.Status,
+				Reason:  gwv1.GatewayConditionReason(condition.Reason),
+				Message: condition.Message,
+			})
+		}
+	}
+
 	addMissingGatewayConditions(r.Gateway(&gw), &gw)
 
 	finalConditions := make([]metav1.Condition, 0)
@@ -430,6 +444,86 @@ func ParentString(ref gwv1.ParentReference) string {
 		ptr.OrEmpty(ref.Namespace))
 }
 
+// AggregateGatewayConditions rolls up the Programmed conditions of the provided listeners into a Gateway Programmed condition.
+// If any listener is not programmed, the message lists the Programmed message of each such listener.
+// The Gateway is only reported as not programmed when none of its listeners are, with the Pending reason if every listener
+// is pending and the Invalid reason otherwise. When only some listeners fail, the Gateway still serves the others,
+// so it remains programmed. Listeners which are only waiting for routes to be attached (e.g. a TCP listener before its
+// TCPRoute is created) do not make the Gateway not programmed on their own, as that is a transient, expected state.
+func AggregateGatewayConditions(listeners []gwv1.ListenerStatus) []metav1.Condition {
+	var messages []string
+	unhealthy, failed := 0, 0
+	allPending := true
+	for _, lis := range listeners {
+		cond := meta.FindStatusCondition(lis.Conditions, string(gwv1.ListenerConditionProgrammed))
+		if cond == nil || cond.Status != metav1.ConditionFalse {
+			continue
+		}
+		message := cond.Message
+		if message == "" {
+			message = cond.Reason
+		}
+		messages = append(messages, fmt.Sprintf("%s: %s", lis.Name, message))
+		unhealthy++
+		if !awaitingRoutes(lis) {
+			failed++
+		}
+		if cond.Reason != string(gwv1.ListenerReasonPending) {
+			allPending = false
+		}
+	}
+
+	if unhealthy == 0 {
+		return []metav1.Condition{{
+			Type:    string(gwv1.GatewayConditionProgrammed),
+			Status:  metav1.ConditionTrue,
+			Reason:  string(gwv1.GatewayReasonProgrammed),
+			Message: GatewayProgrammedMessage,
+		}}
+	}
+
+	if unhealthy < len(listeners) || failed == 0 {
+		return []metav1.Condition{{
+			Type:    string(gwv1.GatewayConditionProgrammed),
+			Status:  metav1.ConditionTrue,
+			Reason:  string(gwv1.GatewayReasonProgrammed),
+			Message: fmt.Sprintf("Some listeners are not programmed: %s", strings.Join(messages, "; ")),
+		}}
+	}
+
+	reason := gwv1.GatewayReasonInvalid
+	if allPending {
+		reason = gwv1.GatewayReasonPending
+	}
+	return []metav1.Condition{{
+		Type:    string(gwv1.GatewayConditionProgrammed),
+		Status:  metav1.ConditionFalse,
+		Reason:  string(reason),
+		Message: fmt.Sprintf("No listeners are programmed: %s", strings.Join(messages, "; ")),
+	}}
+}
+
+// awaitingRoutes reports whether a listener that is not programmed has no attached routes and no other failing condition,
+// i.e. it is not programmed only because there is nothing to route to yet.
+func awaitingRoutes(lis gwv1.ListenerStatus) bool {
+	if lis.AttachedRoutes > 0 {
+		return false
+	}
+	for _, cond := range lis.Conditions {
+		switch gwv1.ListenerConditionType(cond.Type) {
+		case gwv1.ListenerConditionAccepted, gwv1.ListenerConditionResolvedRefs:
+			if cond.Status == metav1.ConditionFalse {
+				return false
+			}
+		case gwv1.ListenerConditionConflicted:
+			if cond.Status == metav1.ConditionTrue {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 // Reports will initially only contain negative conditions found during translation,
 // so all missing conditions are assumed to be positive. Here we will add all missing conditions
 // to a given report, i.e. set healthy conditions
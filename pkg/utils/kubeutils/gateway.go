@@ -0,0 +1,68 @@
+package kubeutils
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gwclient "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
+	gwinformers "sigs.k8s.io/gateway-api/pkg/client/informers/externalversions"
+)
+
+// WatchGatewayAddress watches the Gateway identified by gw, and sends the value of its first
+// status address on the returned channel each time that address changes.
+// Consecutive duplicate addresses are not sent, and a Gateway without an address is ignored.
+// The channel is closed once the context is cancelled and the underlying informer has stopped.
+func WatchGatewayAddress(ctx context.Context, cli gwclient.Interface, gw types.NamespacedName) (<-chan string, error) {
+	factory := gwinformers.NewSharedInformerFactoryWithOptions(cli, 0,
+		gwinformers.WithNamespace(gw.Namespace),
+		gwinformers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+			opts.FieldSelector = fields.OneTermEqualSelector("metadata.name", gw.Name).String()
+		}),
+	)
+	informer := factory.Gateway().V1().Gateways().Informer()
+
+	addresses := make(chan string)
+	// lastAddress is only accessed from the event handler, which the informer never invokes concurrently
+	var lastAddress string
+	onEvent := func(obj any) {
+		gateway, ok := obj.(*gwv1.Gateway)
+		if !ok || gateway.GetName() != gw.Name || gateway.GetNamespace() != gw.Namespace {
+			return
+		}
+		if len(gateway.Status.Addresses) == 0 {
+			return
+		}
+		address := gateway.Status.Addresses[0].Value
+		if address == lastAddress {
+			return
+		}
+		select {
+		case addresses <- address:
+			lastAddress = address
+		case <-ctx.Done():
+		}
+	}
+	_, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    onEvent,
+		UpdateFunc: func(_, newObj any) { onEvent(newObj) },
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch Gateway %s: %w", gw, err)
+	}
+
+	factory.Start(ctx.Done())
+	go func() {
+		<-ctx.Done()
+		// Shutdown blocks until the informer, and therefore the event handler, has stopped,
+		// so it is safe to close the channel afterwards
+		factory.Shutdown()
+		close(addresses)
+	}()
+
+	return addresses, nil
+}
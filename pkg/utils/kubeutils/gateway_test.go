@@ -0,0 +1,76 @@
+package kubeutils
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/fake"
+)
+
+func TestWatchGatewayAddress(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	gw := &gwv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "default"},
+	}
+	other := &gwv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default"},
+	}
+	cli := fake.NewSimpleClientset()
+	for _, g := range []*gwv1.Gateway{gw, other} {
+		if _, err := cli.GatewayV1().Gateways(g.Namespace).Create(ctx, g, metav1.CreateOptions{}); err != nil {
+			t.Fatalf("failed to create gateway: %v", err)
+		}
+	}
+
+	addresses, err := WatchGatewayAddress(ctx, cli, types.NamespacedName{Name: gw.Name, Namespace: gw.Namespace})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	setAddress := func(g *gwv1.Gateway, address string) {
+		t.Helper()
+		g = g.DeepCopy()
+		g.Status.Addresses = []gwv1.GatewayStatusAddress{{Value: address}}
+		// bump a label as well, so that updates with a duplicate address still produce an event
+		g.Labels = map[string]string{"generation": time.Now().String()}
+		if _, err := cli.GatewayV1().Gateways(g.Namespace).Update(ctx, g, metav1.UpdateOptions{}); err != nil {
+			t.Fatalf("failed to update gateway: %v", err)
+		}
+	}
+	expectAddress := func(want string) {
+		t.Helper()
+		select {
+		case got := <-addresses:
+			if got != want {
+				t.Fatalf("expected address %q, got %q", want, got)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for address %q", want)
+		}
+	}
+
+	setAddress(other, "9.9.9.9")
+	setAddress(gw, "1.1.1.1")
+	expectAddress("1.1.1.1")
+
+	// duplicate addresses are not sent, so the next value received is the changed address
+	setAddress(gw, "1.1.1.1")
+	setAddress(gw, "2.2.2.2")
+	expectAddress("2.2.2.2")
+
+	cancel()
+	select {
+	case address, ok := <-addresses:
+		if ok {
+			t.Fatalf("expected channel to be closed, got address %q", address)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for channel to close")
+	}
+}
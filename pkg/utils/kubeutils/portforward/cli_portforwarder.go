@@ -57,6 +57,7 @@ func (c *cliPortForwarder) startOnce(ctx context.Context) error {
 	}
 
 	cmdCtx, cmdCancel := context.WithCancel(ctx)
+	c.cmdCancel = cmdCancel
 	c.cmd = exec.CommandContext( //nolint:gosec // G204: kubectl port-forward with controlled parameters from port forwarder config
 		cmdCtx,
 		"kubectl",
@@ -90,8 +91,6 @@ func (c *cliPortForwarder) startOnce(ctx context.Context) error {
 		return err
 	}
 
-	c.cmdCancel = cmdCancel
-
 	c.errCh = make(chan error, 1)
 
 	// short circuit error return if we can't even start
@@ -133,7 +132,7 @@ func (c *cliPortForwarder) ErrChan() <-chan error {
 }
 
 func (c *cliPortForwarder) WaitForStop() {
-	if c.cmd.Process != nil {
+	if c.cmd != nil && c.cmd.Process != nil {
 		c.errCh <- c.cmd.Wait()
 	}
 }
@@ -32,7 +32,7 @@ func WithKubeContext(kubeContext string) Option {
 	}
 }
 
-// WithResourceSelector takes a kubectl-style selector like `deployment/<name>`
+// WithResourceSelector takes a kubectl-style selector like `deployment/<name>`, `service/<name>`
 // or `pod/<name>` and tries to construct the correct Option for it.
 //
 // If no `<resource>/<name>` style selector supplied, assumes a raw pod name has been provided.
@@ -42,6 +42,8 @@ func WithResourceSelector(resourceSelector, namespace string) Option {
 			return WithDeployment(sel[1], namespace)
 		} else if strings.HasPrefix(sel[0], "po") {
 			return WithPod(sel[1], namespace)
+		} else if strings.HasPrefix(sel[0], "svc") || strings.HasPrefix(sel[0], "service") {
+			return WithService(sel[1], namespace)
 		}
 	}
 	return WithPod(resourceSelector, namespace)
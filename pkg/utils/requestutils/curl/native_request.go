@@ -2,17 +2,27 @@ package curl
 
 import (
 	"bytes"
+	"compress/gzip"
+	"compress/zlib"
 	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
+	"net/http/cookiejar"
 	"net/url"
+	"slices"
 	"strings"
+	"syscall"
 	"time"
 )
 
+// defaultMaxRedirects is the number of redirects followed by native requests unless WithMaxRedirects is set,
+// matching the net/http default
+const defaultMaxRedirects = 10
+
 // ExecuteRequest accepts a set of Option and executes a native Go HTTP request
 // If multiple Option modify the same parameter, the last defined one will win
 //
@@ -23,6 +33,88 @@ import (
 //
 // A notable exception is the WithHeader option, which accumulates headers
 func ExecuteRequest(options ...Option) (*http.Response, error) {
+	return ExecuteRequestWithContext(context.Background(), options...)
+}
+
+// ExecuteRequestWithContext behaves like ExecuteRequest, but executes the request using the provided context
+// Cancelling the context aborts the request, including reading the response body,
+// which allows callers to stop consuming long-lived responses such as event streams.
+func ExecuteRequestWithContext(ctx context.Context, options ...Option) (*http.Response, error) {
+	config, err := newNativeRequestConfig(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return config.executeNative(ctx, config.buildHTTPClient())
+}
+
+// ExecuteRequestWithClient behaves like ExecuteRequest, but executes the request using the provided client
+// This allows callers to share a connection pool across requests, or to inject a custom transport.
+// Since the client is used as is, options which configure the client or its transport
+// (e.g. TLS, HTTP version, resolution and dialer options) are ignored.
+func ExecuteRequestWithClient(client *http.Client, options ...Option) (*http.Response, error) {
+	config, err := newNativeRequestConfig(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return config.executeNative(context.Background(), client)
+}
+
+// BuildClient returns the client ExecuteRequest would use for the provided options
+// Sharing the client across requests made through ExecuteRequestWithClient reuses its connections,
+// as a long-lived client would, rather than opening a new connection for each request.
+func BuildClient(options ...Option) (*http.Client, error) {
+	config, err := newNativeRequestConfig(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return config.buildHTTPClient(), nil
+}
+
+// BuildClientWithCookieJar returns the client ExecuteRequest would use for the provided options, with an in-memory cookie jar
+// Cookies set by responses are stored in the jar and sent on subsequent requests made with the client through
+// ExecuteRequestWithClient, which allows multi-step flows such as logging in and then accessing a resource.
+func BuildClientWithCookieJar(options ...Option) (*http.Client, error) {
+	client, err := BuildClient(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, err
+	}
+	client.Jar = jar
+	return client, nil
+}
+
+// BuildTransport returns the transport and request headers that ExecuteRequest would use for the provided options
+// This allows clients which do not go through net/http, such as WebSocket dialers, to honour the same
+// TLS, resolution and dialer options. The Host header and cookie, if configured, are included in the returned headers.
+func BuildTransport(options ...Option) (*http.Transport, http.Header, error) {
+	config, err := newNativeRequestConfig(options...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	header := http.Header{}
+	for key, values := range config.headers {
+		for _, value := range values {
+			header.Add(key, value)
+		}
+	}
+	if cookie := config.cookieHeader(); cookie != "" {
+		header.Add("Cookie", cookie)
+	}
+
+	transport, _ := config.buildHTTPClient().Transport.(*http.Transport)
+	return transport, header, nil
+}
+
+// newNativeRequestConfig returns the requestConfig for a native request with the provided options applied
+func newNativeRequestConfig(options ...Option) (*requestConfig, error) {
 	config := &requestConfig{
 		verbose:           false,
 		ignoreServerCert:  false,
@@ -41,37 +133,32 @@ func ExecuteRequest(options ...Option) (*http.Response, error) {
 		retryMaxTime:      0,
 		ipv4Only:          false,
 		ipv6Only:          false,
-		cookie:            "",
-		queryParameters:   make(map[string]string),
+		maxRedirects:      -1,
+		queryParameters:   url.Values{},
 	}
 
 	for _, opt := range options {
 		opt(config)
 	}
-
-	return config.executeNative()
+	if config.err != nil {
+		return nil, config.err
+	}
+	return config, nil
 }
 
-func (c *requestConfig) executeNative() (*http.Response, error) {
+func (c *requestConfig) executeNative(ctx context.Context, client *http.Client) (*http.Response, error) {
 	// Build URL
 	fullURL := c.buildURL()
 
-	// Create HTTP client with custom transport
-	client := c.buildHTTPClient()
-
+	if err := c.validateMethod(); err != nil {
+		return nil, err
+	}
 	method := c.method
-
-	// Prepare request body
-	var bodyReader io.Reader
-	if c.body != "" {
-		bodyReader = bytes.NewBufferString(c.body)
-		if method == "" {
-			method = "POST"
-		}
+	if c.body != "" && method == "" {
+		method = "POST"
 	}
 
 	// Create context with timeout
-	ctx := context.Background()
 	if c.connectionTimeout > 0 {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(ctx, time.Duration(c.connectionTimeout)*time.Second)
@@ -81,6 +168,56 @@ func (c *requestConfig) executeNative() (*http.Response, error) {
 		method = "GET"
 	}
 
+	start := time.Now()
+	for attempt := 1; ; attempt++ {
+		req, err := c.buildRequest(context.WithValue(ctx, attemptsKey{}, attempt), method, fullURL)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := c.do(client, req)
+		if err == nil && !(c.retryTransientResponses && slices.Contains(transientStatusCodes, resp.StatusCode)) {
+			return resp, nil
+		}
+
+		delay, retry := c.retryDelayFor(attempt, req.Method, err, time.Since(start))
+		if !retry {
+			if err == nil {
+				// the transient response is returned as is, like curl does once retries are exhausted
+				return resp, nil
+			}
+			if attempt > 1 {
+				return nil, fmt.Errorf("request failed after %d attempts: %w", attempt, err)
+			}
+			return nil, err
+		}
+		if err == nil {
+			io.Copy(io.Discard, resp.Body) //nolint:errcheck // drained only so the connection can be reused
+			resp.Body.Close()
+			err = fmt.Errorf("transient response: %s", resp.Status)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("request failed after %d attempts: %w", attempt, err)
+		case <-time.After(delay):
+		}
+	}
+}
+
+// buildRequest creates a new request for a single attempt
+// A new body reader is created on each call so that retried requests resend the full body
+func (c *requestConfig) buildRequest(ctx context.Context, method, fullURL string) (*http.Request, error) {
+	// Prepare request body
+	var bodyReader io.Reader
+	if c.body != "" {
+		body, err := c.encodeBody()
+		if err != nil {
+			return nil, err
+		}
+		bodyReader = bytes.NewReader(body)
+	}
+
 	// Create request
 	req, err := http.NewRequestWithContext(ctx, method, fullURL, bodyReader)
 	if err != nil {
@@ -99,17 +236,58 @@ func (c *requestConfig) executeNative() (*http.Response, error) {
 		}
 	}
 
+	if c.disableAutoDecompress && req.Header.Get("Accept-Encoding") == "" {
+		// net/http only requests gzip itself when it will also decompress the response
+		req.Header.Set("Accept-Encoding", "gzip")
+	}
+	if c.body != "" && c.bodyEncoding != "" {
+		req.Header.Set("Content-Encoding", c.bodyEncoding)
+	}
+
 	// Add cookies
 	if c.cookie != "" {
 		req.Header.Add("Cookie", c.cookie)
 	}
+	for _, cookie := range c.cookies {
+		req.AddCookie(cookie)
+	}
 
 	// Handle HEAD-only requests
 	if c.headersOnly {
 		req.Method = "HEAD"
 	}
 
-	// Execute request
+	return req, nil
+}
+
+// encodeBody returns the request body, compressed with bodyEncoding if one is configured
+func (c *requestConfig) encodeBody() ([]byte, error) {
+	var (
+		buf bytes.Buffer
+		w   io.WriteCloser
+	)
+	switch c.bodyEncoding {
+	case "":
+		return []byte(c.body), nil
+	case EncodingGzip:
+		w = gzip.NewWriter(&buf)
+	case EncodingDeflate:
+		// the deflate content coding is the zlib format, see RFC 9110 section 8.4.1.2
+		w = zlib.NewWriter(&buf)
+	default:
+		return nil, fmt.Errorf("unsupported body encoding %q", c.bodyEncoding)
+	}
+	if _, err := io.WriteString(w, c.body); err != nil {
+		return nil, fmt.Errorf("failed to compress request body: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress request body: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// do executes a single attempt of the request
+func (c *requestConfig) do(client *http.Client, req *http.Request) (*http.Response, error) {
 	if c.verbose {
 		fmt.Printf("> %s %s\n", req.Method, req.URL.String())
 		fmt.Printf("> Host: %s\n", req.Host)
@@ -136,34 +314,124 @@ func (c *requestConfig) executeNative() (*http.Response, error) {
 	return resp, nil
 }
 
+// idempotentMethods is the set of methods that are safe to retry after a connection-level failure
+var idempotentMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodOptions,
+	http.MethodTrace,
+	http.MethodPut,
+	http.MethodDelete,
+}
+
+// transientStatusCodes are the response status codes which curl considers transient, and retries
+// Native requests only retry them when enabled with WithRetryTransientResponses
+// https://curl.se/docs/manpage.html#--retry
+var transientStatusCodes = []int{
+	http.StatusRequestTimeout,
+	http.StatusTooManyRequests,
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// maxRetryBackoff caps the exponential backoff between retries, like curl does
+const maxRetryBackoff = 10 * time.Minute
+
+// retryDelayFor determines whether a failed attempt should be retried, and how long to wait before doing so.
+// err is nil when the attempt received a transient response.
+// It mirrors the semantics of the curl retry flags configured via WithRetries and WithRetryConnectionRefused:
+//   - connection-level failures are retried, and refused connections only if explicitly enabled
+//   - without an explicit delay, the backoff starts at one second and doubles on each attempt, up to 10 minutes
+//   - no retry is started once the max retry time has elapsed
+//
+// Additionally, only idempotent methods are retried, as a failed request may have reached the server.
+func (c *requestConfig) retryDelayFor(attempt int, method string, err error, elapsed time.Duration) (time.Duration, bool) {
+	if attempt > c.retry {
+		return 0, false
+	}
+	if !slices.Contains(idempotentMethods, method) {
+		return 0, false
+	}
+	if errors.Is(err, syscall.ECONNREFUSED) && !c.retryConnectionRefused {
+		return 0, false
+	}
+	if c.retryMaxTime > 0 && elapsed >= time.Duration(c.retryMaxTime)*time.Second {
+		return 0, false
+	}
+
+	if c.retryDelay >= 0 {
+		return time.Duration(c.retryDelay) * time.Second, true
+	}
+	// 2^10 seconds already exceeds the cap, so limiting the shift also keeps it from overflowing
+	return min(time.Second<<min(attempt-1, 10), maxRetryBackoff), true
+}
+
+// attemptsKey is the context key used to record the attempt number on each request
+type attemptsKey struct{}
+
+// Attempts returns the number of attempts ExecuteRequest made before receiving the provided response.
+// It returns 0 if the response was not produced by ExecuteRequest.
+func Attempts(resp *http.Response) int {
+	if resp == nil || resp.Request == nil {
+		return 0
+	}
+	attempts, _ := resp.Request.Context().Value(attemptsKey{}).(int)
+	return attempts
+}
+
+// standardMethods is the set of HTTP methods accepted by WithMethod for native requests
+var standardMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+	http.MethodConnect,
+	http.MethodOptions,
+	http.MethodTrace,
+}
+
+// validateMethod returns an error if the method was explicitly set to an empty or non-standard value
+func (c *requestConfig) validateMethod() error {
+	if !c.methodSet {
+		return nil
+	}
+	if c.method == "" {
+		return fmt.Errorf("invalid method: method must not be empty")
+	}
+	if !slices.Contains(standardMethods, c.method) {
+		return fmt.Errorf("invalid method %q: must be one of %s", c.method, strings.Join(standardMethods, ", "))
+	}
+	return nil
+}
+
 func (c *requestConfig) buildURL() string {
 	path := c.path
 	if path != "" && !strings.HasPrefix(path, "/") {
 		path = "/" + path
 	}
 
-	baseURL := fmt.Sprintf("%s://%s:%d%s", c.scheme, c.host, c.port, path)
-
-	if len(c.queryParameters) > 0 {
-		values := url.Values{}
-		for k, v := range c.queryParameters {
-			values.Add(k, v)
-		}
-		return fmt.Sprintf("%s?%s", baseURL, values.Encode())
-	}
-
-	return baseURL
+	return c.appendQuery(fmt.Sprintf("%s://%s:%d%s", c.scheme, c.host, c.port, path))
 }
 
 func (c *requestConfig) buildHTTPClient() *http.Client {
 	transport := &http.Transport{
-		DialContext: c.buildDialer(),
+		DialContext:         c.buildDialer(),
+		MaxIdleConns:        c.maxIdleConns,
+		MaxIdleConnsPerHost: c.maxIdleConnsPerHost,
+		IdleConnTimeout:     c.idleConnTimeout,
+		DisableCompression:  c.disableAutoDecompress,
 	}
 
 	// Configure TLS
-	if c.scheme == "https" || c.ignoreServerCert || c.sni != "" {
+	if c.scheme == "https" || c.ignoreServerCert || c.sni != "" || c.rootCAs != nil || len(c.clientCertificates) > 0 {
 		tlsConfig := &tls.Config{
 			InsecureSkipVerify: c.ignoreServerCert, // nolint: gosec // this is for tests
+			RootCAs:            c.rootCAs,
+			Certificates:       c.clientCertificates,
 		}
 
 		if c.sni != "" {
@@ -195,8 +463,16 @@ func (c *requestConfig) buildHTTPClient() *http.Client {
 
 	// Configure HTTP version
 	if c.http2 {
-		// HTTP/2 is enabled by default in Go's transport
 		transport.ForceAttemptHTTP2 = true
+		transport.Protocols = new(http.Protocols)
+		if c.scheme == "https" {
+			// Negotiate HTTP/2 via ALPN, falling back to HTTP/1.1 if the server does not support it
+			transport.Protocols.SetHTTP1(true)
+			transport.Protocols.SetHTTP2(true)
+		} else {
+			// Use HTTP/2 over cleartext with prior knowledge, as there is no ALPN to negotiate it
+			transport.Protocols.SetUnencryptedHTTP2(true)
+		}
 	} else if c.http11 {
 		// Disable HTTP/2 to force HTTP/1.1
 		transport.ForceAttemptHTTP2 = false
@@ -212,11 +488,25 @@ func (c *requestConfig) buildHTTPClient() *http.Client {
 		client.Timeout = time.Duration(c.connectionTimeout) * time.Second
 	}
 
-	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
-		// Disable redirects
+	client.CheckRedirect = c.checkRedirect
+	return client
+}
+
+// checkRedirect is the http.Client CheckRedirect policy for the configured redirect options
+// Unless redirects are followed, the redirect response itself is returned to the caller
+func (c *requestConfig) checkRedirect(_ *http.Request, via []*http.Request) error {
+	if !c.followRedirects {
 		return http.ErrUseLastResponse
 	}
-	return client
+	maxRedirects := c.maxRedirects
+	if maxRedirects < 0 {
+		maxRedirects = defaultMaxRedirects
+	}
+	// via holds the requests made so far, so its length is the number of redirects received
+	if len(via) > maxRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxRedirects)
+	}
+	return nil
 }
 
 func (c *requestConfig) buildDialer() func(context.Context, string, string) (net.Conn, error) {
@@ -224,20 +514,19 @@ func (c *requestConfig) buildDialer() func(context.Context, string, string) (net
 		Timeout: 30 * time.Second,
 	}
 
-	if c.connectionTimeout > 0 {
+	if c.connectTimeout > 0 {
+		dialer.Timeout = c.connectTimeout
+	} else if c.connectionTimeout > 0 {
 		dialer.Timeout = time.Duration(c.connectionTimeout) * time.Second
 	}
 
 	// Handle IPv4/IPv6 restrictions
+	networkOverride := ""
 	if c.ipv4Only {
-		return func(ctx context.Context, network, addr string) (net.Conn, error) {
-			return dialer.DialContext(ctx, "tcp4", addr)
-		}
+		networkOverride = "tcp4"
 	}
 	if c.ipv6Only {
-		return func(ctx context.Context, network, addr string) (net.Conn, error) {
-			return dialer.DialContext(ctx, "tcp6", addr)
-		}
+		networkOverride = "tcp6"
 	}
 
 	// Handle SNI with custom host resolution
@@ -246,7 +535,30 @@ func (c *requestConfig) buildDialer() func(context.Context, string, string) (net
 		panic("sni is not implemented")
 	}
 
-	return dialer.DialContext
+	// Every connection goes to the unix socket, whatever the address of the request
+	if c.unixSocket != "" {
+		return func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, "unix", c.unixSocket)
+		}
+	}
+
+	if networkOverride == "" && len(c.resolve) == 0 {
+		return dialer.DialContext
+	}
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if networkOverride != "" {
+			network = networkOverride
+		}
+		// Connect to the overridden address for a matching host and port, keeping the original port
+		if resolved, ok := c.resolve[addr]; ok {
+			_, port, err := net.SplitHostPort(addr)
+			if err != nil {
+				return nil, err
+			}
+			addr = net.JoinHostPort(resolved, port)
+		}
+		return dialer.DialContext(ctx, network, addr)
+	}
 }
 
 func parseTLSVersion(version string) uint16 {
@@ -0,0 +1,1089 @@
+package curl_test
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing/iotest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/utils/requestutils/curl"
+)
+
+var _ = Describe("ExecuteRequest", func() {
+
+	var (
+		server *httptest.Server
+		// lastRequest is the most recent request received by the server
+		lastRequest *http.Request
+		// lastBody is the body of the most recent request received by the server
+		lastBody string
+	)
+
+	BeforeEach(func() {
+		lastRequest = nil
+		lastBody = ""
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			lastRequest = r
+			b, _ := io.ReadAll(r.Body)
+			lastBody = string(b)
+			w.WriteHeader(http.StatusOK)
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	// serverOpts returns the set of options needed to reach the test server
+	serverOpts := func(opts ...curl.Option) []curl.Option {
+		return append([]curl.Option{curl.WithHostPort(strings.TrimPrefix(server.URL, "http://"))}, opts...)
+	}
+
+	Context("ExecuteRequestWithClient", func() {
+
+		It("executes requests using the provided client", func() {
+			var newConnections int
+			dialer := &net.Dialer{}
+			transport := &countingTransport{RoundTripper: &http.Transport{
+				DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+					newConnections++
+					return dialer.DialContext(ctx, network, addr)
+				},
+			}}
+			client := &http.Client{Transport: transport}
+
+			for range 2 {
+				resp, err := curl.ExecuteRequestWithClient(client, serverOpts(curl.WithPath("path"))...)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = io.Copy(io.Discard, resp.Body)
+				Expect(err).NotTo(HaveOccurred())
+				resp.Body.Close()
+				Expect(lastRequest.URL.Path).To(Equal("/path"))
+			}
+
+			Expect(transport.requests).To(Equal(2))
+			// the connection is reused across requests
+			Expect(newConnections).To(Equal(1))
+		})
+
+		It("returns option errors without executing the request", func() {
+			transport := &countingTransport{RoundTripper: &http.Transport{}}
+			resp, err := curl.ExecuteRequestWithClient(&http.Client{Transport: transport}, serverOpts(curl.WithMethod(""))...)
+			Expect(err).To(HaveOccurred())
+			Expect(resp).To(BeNil())
+			Expect(transport.requests).To(BeZero())
+		})
+	})
+
+	Context("WithCookie", func() {
+
+		It("sends the configured cookies", func() {
+			_, err := curl.ExecuteRequest(serverOpts(curl.WithNamedCookie("session", "abc"), curl.WithNamedCookie("theme", "dark"))...)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lastRequest.Cookies()).To(HaveLen(2))
+			Expect(lastRequest.Header.Get("Cookie")).To(Equal("session=abc; theme=dark"))
+		})
+
+		It("sends a raw cookie along with named cookies", func() {
+			_, err := curl.ExecuteRequest(serverOpts(curl.WithCookie("api-key=k-123"), curl.WithNamedCookie("session", "abc"))...)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lastRequest.Header.Get("Cookie")).To(Equal("api-key=k-123; session=abc"))
+		})
+	})
+
+	Context("BuildClient", func() {
+
+		It("reuses connections across requests", func() {
+			var conns atomic.Int32
+			reuseServer := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+			reuseServer.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+				if state == http.StateNew {
+					conns.Add(1)
+				}
+			}
+			reuseServer.Start()
+			defer reuseServer.Close()
+			reuseOpts := []curl.Option{curl.WithHostPort(strings.TrimPrefix(reuseServer.URL, "http://"))}
+
+			client, err := curl.BuildClient(reuseOpts...)
+			Expect(err).NotTo(HaveOccurred())
+			for range 3 {
+				resp, err := curl.ExecuteRequestWithClient(client, reuseOpts...)
+				Expect(err).NotTo(HaveOccurred())
+				_, _ = io.Copy(io.Discard, resp.Body)
+				resp.Body.Close()
+			}
+			Expect(conns.Load()).To(Equal(int32(1)))
+		})
+
+		It("returns option errors", func() {
+			client, err := curl.BuildClient(curl.WithMaxRedirects(-1))
+			Expect(err).To(HaveOccurred())
+			Expect(client).To(BeNil())
+		})
+	})
+
+	Context("BuildClientWithCookieJar", func() {
+
+		It("round-trips cookies set by responses across requests", func() {
+			var sessions []string
+			sessionServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/login" {
+					http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc", Path: "/"})
+					return
+				}
+				session, err := r.Cookie("session")
+				if err != nil {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				sessions = append(sessions, session.Value)
+			}))
+			defer sessionServer.Close()
+			sessionOpts := func(path string) []curl.Option {
+				return []curl.Option{curl.WithHostPort(strings.TrimPrefix(sessionServer.URL, "http://")), curl.WithPath(path)}
+			}
+
+			client, err := curl.BuildClientWithCookieJar()
+			Expect(err).NotTo(HaveOccurred())
+
+			resp, err := curl.ExecuteRequestWithClient(client, sessionOpts("/resource")...)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+
+			resp, err = curl.ExecuteRequestWithClient(client, sessionOpts("/login")...)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+			resp, err = curl.ExecuteRequestWithClient(client, sessionOpts("/resource")...)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			Expect(sessions).To(Equal([]string{"abc"}))
+		})
+
+		It("returns option errors", func() {
+			client, err := curl.BuildClientWithCookieJar(curl.WithMaxRedirects(-1))
+			Expect(err).To(HaveOccurred())
+			Expect(client).To(BeNil())
+		})
+	})
+
+	Context("ExecuteRequestWithContext", func() {
+
+		It("executes the request", func() {
+			resp, err := curl.ExecuteRequestWithContext(context.Background(), serverOpts(curl.WithPath("path"))...)
+			Expect(err).NotTo(HaveOccurred())
+			resp.Body.Close()
+			Expect(lastRequest.URL.Path).To(Equal("/path"))
+		})
+
+		It("aborts reading the response body once the context is cancelled", func() {
+			streaming := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				w.(http.Flusher).Flush()
+				<-r.Context().Done()
+			}))
+			defer streaming.Close()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			resp, err := curl.ExecuteRequestWithContext(ctx, curl.WithHostPort(strings.TrimPrefix(streaming.URL, "http://")))
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+
+			cancel()
+			_, err = io.ReadAll(resp.Body)
+			Expect(err).To(MatchError(context.Canceled))
+		})
+	})
+
+	Context("BuildTransport", func() {
+
+		It("returns the configured headers", func() {
+			_, header, err := curl.BuildTransport(
+				curl.WithHostHeader("example.com"),
+				curl.WithHeader("x-test", "value"),
+				curl.WithNamedCookie("session", "abc"),
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(header).To(Equal(http.Header{
+				"Host":   []string{"example.com"},
+				"X-Test": []string{"value"},
+				"Cookie": []string{"session=abc"},
+			}))
+		})
+
+		It("returns a transport which honours the dialer and TLS options", func() {
+			host, portStr, err := net.SplitHostPort(strings.TrimPrefix(server.URL, "http://"))
+			Expect(err).NotTo(HaveOccurred())
+			port, err := strconv.Atoi(portStr)
+			Expect(err).NotTo(HaveOccurred())
+
+			transport, _, err := curl.BuildTransport(
+				curl.WithResolve("example.invalid", port, host),
+				curl.IgnoreServerCert(),
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(transport.TLSClientConfig.InsecureSkipVerify).To(BeTrue())
+
+			conn, err := transport.DialContext(context.Background(), "tcp", net.JoinHostPort("example.invalid", portStr))
+			Expect(err).NotTo(HaveOccurred())
+			conn.Close()
+		})
+
+		It("returns option errors", func() {
+			transport, header, err := curl.BuildTransport(curl.WithCACert([]byte("invalid")))
+			Expect(err).To(HaveOccurred())
+			Expect(transport).To(BeNil())
+			Expect(header).To(BeNil())
+		})
+	})
+
+	Context("WithConnectionPool", func() {
+
+		var (
+			pooled *httptest.Server
+			// newConns counts the connections accepted by the pooled server
+			newConns atomic.Int32
+		)
+
+		BeforeEach(func() {
+			newConns.Store(0)
+			pooled = httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+			pooled.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+				if state == http.StateNew {
+					newConns.Add(1)
+				}
+			}
+			pooled.Start()
+		})
+
+		AfterEach(func() {
+			pooled.Close()
+		})
+
+		// executeWith executes count sequential requests against the pooled server using client,
+		// waiting for wait between requests
+		executeWith := func(client *http.Client, count int, wait time.Duration) {
+			for i := range count {
+				if i > 0 {
+					time.Sleep(wait)
+				}
+				resp, err := curl.ExecuteRequestWithClient(client, curl.WithHostPort(strings.TrimPrefix(pooled.URL, "http://")))
+				Expect(err).NotTo(HaveOccurred())
+				io.Copy(io.Discard, resp.Body) //nolint:errcheck
+				resp.Body.Close()
+			}
+		}
+
+		It("configures the transport", func() {
+			transport, _, err := curl.BuildTransport(curl.WithConnectionPool(10, 5, time.Minute))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(transport.MaxIdleConns).To(Equal(10))
+			Expect(transport.MaxIdleConnsPerHost).To(Equal(5))
+			Expect(transport.IdleConnTimeout).To(Equal(time.Minute))
+		})
+
+		It("reuses idle connections across requests", func() {
+			transport, _, err := curl.BuildTransport(curl.WithConnectionPool(1, 1, time.Minute))
+			Expect(err).NotTo(HaveOccurred())
+			defer transport.CloseIdleConnections()
+
+			executeWith(&http.Client{Transport: transport}, 3, 0)
+			Expect(newConns.Load()).To(BeEquivalentTo(1))
+		})
+
+		It("closes connections which exceed the idle timeout", func() {
+			transport, _, err := curl.BuildTransport(curl.WithConnectionPool(1, 1, 10*time.Millisecond))
+			Expect(err).NotTo(HaveOccurred())
+			defer transport.CloseIdleConnections()
+
+			executeWith(&http.Client{Transport: transport}, 2, 100*time.Millisecond)
+			Expect(newConns.Load()).To(BeEquivalentTo(2))
+		})
+
+		It("returns an error for negative values", func() {
+			_, err := curl.ExecuteRequest(serverOpts(curl.WithConnectionPool(-1, 0, 0))...)
+			Expect(err).To(MatchError(ContainSubstring("invalid connection pool")))
+			Expect(lastRequest).To(BeNil())
+		})
+	})
+
+	Context("WithConnectTimeout", func() {
+
+		It("does not limit the time taken by the response", func() {
+			slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				time.Sleep(200 * time.Millisecond)
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer slow.Close()
+
+			resp, err := curl.ExecuteRequest(
+				curl.WithHostPort(strings.TrimPrefix(slow.URL, "http://")),
+				curl.WithConnectTimeout(50*time.Millisecond),
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		})
+
+		It("returns an error for a negative timeout", func() {
+			_, err := curl.ExecuteRequest(serverOpts(curl.WithConnectTimeout(-time.Second))...)
+			Expect(err).To(MatchError(ContainSubstring("invalid connect timeout")))
+			Expect(lastRequest).To(BeNil())
+		})
+	})
+
+	Context("WithUnixSocket", func() {
+
+		It("sends the request over the unix socket", func() {
+			socket := filepath.Join(GinkgoT().TempDir(), "admin.sock")
+			listener, err := net.Listen("unix", socket)
+			Expect(err).NotTo(HaveOccurred())
+			var socketRequest *http.Request
+			unixServer := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				socketRequest = r
+			}))
+			unixServer.Listener = listener
+			unixServer.Start()
+			defer unixServer.Close()
+
+			// the host and port are not reachable, so the request can only succeed over the socket
+			resp, err := curl.ExecuteRequest(
+				curl.WithHostPort("admin.invalid:19000"),
+				curl.WithPath("stats"),
+				curl.WithUnixSocket(socket),
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			Expect(socketRequest.Host).To(Equal("admin.invalid:19000"))
+			Expect(socketRequest.URL.Path).To(Equal("/stats"))
+		})
+	})
+
+	Context("WithFollowRedirects", func() {
+
+		var redirecting *httptest.Server
+
+		BeforeEach(func() {
+			// /redirect/<n> redirects n times before landing on /done
+			redirecting = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				remaining, err := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/redirect/"))
+				if err != nil {
+					w.WriteHeader(http.StatusOK)
+					return
+				}
+				location := "/done"
+				if remaining > 1 {
+					location = fmt.Sprintf("/redirect/%d", remaining-1)
+				}
+				http.Redirect(w, r, location, http.StatusMovedPermanently)
+			}))
+		})
+
+		AfterEach(func() {
+			redirecting.Close()
+		})
+
+		redirectOpts := func(opts ...curl.Option) []curl.Option {
+			return append([]curl.Option{curl.WithHostPort(strings.TrimPrefix(redirecting.URL, "http://"))}, opts...)
+		}
+
+		DescribeTable("returns the redirect response when not following",
+			func(opts ...curl.Option) {
+				resp, err := curl.ExecuteRequest(redirectOpts(append(opts, curl.WithPath("/redirect/2"))...)...)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.StatusCode).To(Equal(http.StatusMovedPermanently))
+				Expect(resp.Header.Get("Location")).To(Equal("/redirect/1"))
+			},
+			Entry("by default"),
+			Entry("when disabled", curl.WithFollowRedirects(false)),
+			Entry("with only a max redirects", curl.WithMaxRedirects(5)),
+		)
+
+		It("follows redirects", func() {
+			resp, err := curl.ExecuteRequest(redirectOpts(curl.WithPath("/redirect/3"), curl.WithFollowRedirects(true))...)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			Expect(resp.Request.URL.Path).To(Equal("/done"))
+		})
+
+		It("follows up to the max redirects", func() {
+			resp, err := curl.ExecuteRequest(redirectOpts(curl.WithPath("/redirect/2"), curl.WithFollowRedirects(true), curl.WithMaxRedirects(2))...)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		})
+
+		It("returns an error once the max redirects are exceeded", func() {
+			_, err := curl.ExecuteRequest(redirectOpts(curl.WithPath("/redirect/3"), curl.WithFollowRedirects(true), curl.WithMaxRedirects(2))...)
+			Expect(err).To(MatchError(ContainSubstring("stopped after 2 redirects")))
+		})
+
+		It("returns an error for a negative max redirects", func() {
+			_, err := curl.ExecuteRequest(redirectOpts(curl.WithMaxRedirects(-1))...)
+			Expect(err).To(MatchError(ContainSubstring("invalid max redirects")))
+		})
+	})
+
+	Context("WithMethod", func() {
+
+		It("defaults to GET", func() {
+			resp, err := curl.ExecuteRequest(serverOpts()...)
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(lastRequest.Method).To(Equal(http.MethodGet))
+		})
+
+		DescribeTable("sends the provided method",
+			func(method string) {
+				resp, err := curl.ExecuteRequest(serverOpts(curl.WithMethod(method))...)
+				Expect(err).NotTo(HaveOccurred())
+				defer resp.Body.Close()
+				Expect(lastRequest.Method).To(Equal(method))
+			},
+			Entry("POST", http.MethodPost),
+			Entry("PUT", http.MethodPut),
+			Entry("PATCH", http.MethodPatch),
+			Entry("DELETE", http.MethodDelete),
+			Entry("OPTIONS", http.MethodOptions),
+		)
+
+		DescribeTable("returns an error for an invalid method",
+			func(method string, errSubstring string) {
+				resp, err := curl.ExecuteRequest(serverOpts(curl.WithMethod(method))...)
+				Expect(err).To(MatchError(ContainSubstring(errSubstring)))
+				Expect(resp).To(BeNil())
+				Expect(lastRequest).To(BeNil())
+			},
+			Entry("empty", "", "must not be empty"),
+			Entry("lowercase", "get", `invalid method "get"`),
+			Entry("unknown", "FETCH", `invalid method "FETCH"`),
+		)
+	})
+
+	Context("WithBodyReader", func() {
+
+		It("sends the body read from the reader", func() {
+			resp, err := curl.ExecuteRequest(serverOpts(curl.WithBodyReader(strings.NewReader("hello")))...)
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(lastRequest.Method).To(Equal(http.MethodPost))
+			Expect(lastBody).To(Equal("hello"))
+		})
+
+		It("returns an error if the reader fails", func() {
+			resp, err := curl.ExecuteRequest(serverOpts(curl.WithBodyReader(iotest.ErrReader(errors.New("boom"))))...)
+			Expect(err).To(MatchError(ContainSubstring("failed to read request body: boom")))
+			Expect(resp).To(BeNil())
+			Expect(lastRequest).To(BeNil())
+		})
+	})
+
+	Context("WithJSONBody", func() {
+
+		It("marshals the body and sets the content type", func() {
+			resp, err := curl.ExecuteRequest(serverOpts(curl.WithJSONBody(map[string]string{"key": "value"}))...)
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(lastBody).To(MatchJSON(`{"key": "value"}`))
+			Expect(lastRequest.Header.Get("Content-Type")).To(Equal("application/json"))
+		})
+
+		It("does not override an existing content type", func() {
+			resp, err := curl.ExecuteRequest(serverOpts(
+				curl.WithHeader("content-type", "application/vnd.api+json"),
+				curl.WithJSONBody(map[string]string{"key": "value"}),
+			)...)
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(lastRequest.Header.Values("Content-Type")).To(ConsistOf("application/vnd.api+json"))
+		})
+
+		It("returns an error if the body cannot be marshalled", func() {
+			resp, err := curl.ExecuteRequest(serverOpts(curl.WithJSONBody(make(chan int)))...)
+			Expect(err).To(MatchError(ContainSubstring("failed to marshal JSON request body")))
+			Expect(resp).To(BeNil())
+			Expect(lastRequest).To(BeNil())
+		})
+	})
+
+	Context("WithCompressedBody", func() {
+
+		decompress := func(encoding, body string) string {
+			var (
+				r   io.Reader
+				err error
+			)
+			switch encoding {
+			case curl.EncodingGzip:
+				r, err = gzip.NewReader(strings.NewReader(body))
+			case curl.EncodingDeflate:
+				r, err = zlib.NewReader(strings.NewReader(body))
+			}
+			Expect(err).NotTo(HaveOccurred())
+			b, err := io.ReadAll(r)
+			Expect(err).NotTo(HaveOccurred())
+			return string(b)
+		}
+
+		DescribeTable("compresses the body and sets the content encoding",
+			func(encoding string) {
+				resp, err := curl.ExecuteRequest(serverOpts(curl.WithBody("hello"), curl.WithCompressedBody(encoding))...)
+				Expect(err).NotTo(HaveOccurred())
+				defer resp.Body.Close()
+				Expect(lastRequest.Method).To(Equal(http.MethodPost))
+				Expect(lastRequest.Header.Get("Content-Encoding")).To(Equal(encoding))
+				Expect(decompress(encoding, lastBody)).To(Equal("hello"))
+			},
+			Entry("gzip", curl.EncodingGzip),
+			Entry("deflate", curl.EncodingDeflate),
+		)
+
+		It("composes with a JSON body set afterwards", func() {
+			resp, err := curl.ExecuteRequest(serverOpts(
+				curl.WithCompressedBody(curl.EncodingGzip),
+				curl.WithJSONBody(map[string]string{"key": "value"}),
+			)...)
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(lastRequest.Header.Get("Content-Type")).To(Equal("application/json"))
+			Expect(lastRequest.Header.Get("Content-Encoding")).To(Equal(curl.EncodingGzip))
+			Expect(decompress(curl.EncodingGzip, lastBody)).To(MatchJSON(`{"key": "value"}`))
+		})
+
+		It("does not set the content encoding without a body", func() {
+			resp, err := curl.ExecuteRequest(serverOpts(curl.WithCompressedBody(curl.EncodingGzip))...)
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(lastRequest.Header.Get("Content-Encoding")).To(BeEmpty())
+			Expect(lastBody).To(BeEmpty())
+		})
+
+		It("returns an error for an unsupported encoding", func() {
+			resp, err := curl.ExecuteRequest(serverOpts(curl.WithBody("hello"), curl.WithCompressedBody("br"))...)
+			Expect(err).To(MatchError(ContainSubstring(`unsupported body encoding "br"`)))
+			Expect(resp).To(BeNil())
+			Expect(lastRequest).To(BeNil())
+		})
+	})
+
+	Context("WithDisableAutoDecompress", func() {
+		var gzipServer *httptest.Server
+
+		BeforeEach(func() {
+			gzipServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				lastRequest = r
+				if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+					io.WriteString(w, "hello") //nolint:errcheck
+					return
+				}
+				w.Header().Set("Content-Encoding", "gzip")
+				gz := gzip.NewWriter(w)
+				io.WriteString(gz, "hello") //nolint:errcheck
+				gz.Close()
+			}))
+			DeferCleanup(gzipServer.Close)
+		})
+
+		gzipServerOpts := func(opts ...curl.Option) []curl.Option {
+			return append([]curl.Option{curl.WithHostPort(strings.TrimPrefix(gzipServer.URL, "http://"))}, opts...)
+		}
+
+		It("decompresses responses by default", func() {
+			resp, err := curl.ExecuteRequest(gzipServerOpts()...)
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.Header.Get("Content-Encoding")).To(BeEmpty())
+			body, err := io.ReadAll(resp.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(body)).To(Equal("hello"))
+		})
+
+		It("returns the compressed response as sent", func() {
+			resp, err := curl.ExecuteRequest(gzipServerOpts(curl.WithDisableAutoDecompress())...)
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(lastRequest.Header.Get("Accept-Encoding")).To(Equal("gzip"))
+			Expect(resp.Header.Get("Content-Encoding")).To(Equal("gzip"))
+			gz, err := gzip.NewReader(resp.Body)
+			Expect(err).NotTo(HaveOccurred())
+			body, err := io.ReadAll(gz)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(body)).To(Equal("hello"))
+		})
+
+		It("keeps a configured Accept-Encoding header", func() {
+			resp, err := curl.ExecuteRequest(gzipServerOpts(
+				curl.WithDisableAutoDecompress(),
+				curl.WithHeader("Accept-Encoding", "identity"),
+			)...)
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(lastRequest.Header.Values("Accept-Encoding")).To(ConsistOf("identity"))
+			Expect(resp.Header.Get("Content-Encoding")).To(BeEmpty())
+		})
+	})
+
+	Context("WithQueryParam", func() {
+
+		DescribeTable("encodes the query parameters onto the request URL",
+			func(expectedQuery string, opts ...curl.Option) {
+				resp, err := curl.ExecuteRequest(serverOpts(opts...)...)
+				Expect(err).NotTo(HaveOccurred())
+				defer resp.Body.Close()
+				Expect(lastRequest.URL.RawQuery).To(Equal(expectedQuery))
+			},
+			Entry("single parameter", "key=value",
+				curl.WithQueryParam("key", "value")),
+			Entry("escaped parameter", "key=a+value%26more",
+				curl.WithQueryParam("key", "a value&more")),
+			Entry("multiple values for a key", "key=one&key=two&other=three",
+				curl.WithQueryParam("key", "one"), curl.WithQueryParam("key", "two"), curl.WithQueryParam("other", "three")),
+			Entry("path with a query string", "existing=1&key=value",
+				curl.WithPath("path?existing=1"), curl.WithQueryParam("key", "value")),
+			Entry("added to query parameters", "key=value&other=two",
+				curl.WithQueryParameters(map[string]string{"key": "value"}), curl.WithQueryParam("other", "two")),
+			Entry("replaced by query parameters", "other=two",
+				curl.WithQueryParam("key", "value"), curl.WithQueryParameters(map[string]string{"other": "two"})),
+			Entry("special characters", "q=a+b%2Bc%3Dd%26e",
+				curl.WithQueryParam("q", "a b+c=d&e")),
+			Entry("raw query", "a=%2F&b",
+				curl.WithRawQuery("a=%2F&b")),
+			Entry("raw query with a leading ?", "a=1",
+				curl.WithRawQuery("?a=1")),
+			Entry("raw query replaced", "b=2",
+				curl.WithRawQuery("a=1"), curl.WithRawQuery("b=2")),
+			Entry("raw query before query parameters", "a=%2F&key=value",
+				curl.WithQueryParam("key", "value"), curl.WithRawQuery("a=%2F")),
+			Entry("raw query with a path query string", "existing=1&a=1",
+				curl.WithPath("path?existing=1"), curl.WithRawQuery("a=1")),
+		)
+	})
+
+	Context("WithResolve", func() {
+
+		var port int
+
+		BeforeEach(func() {
+			var err error
+			port, err = strconv.Atoi(server.URL[strings.LastIndex(server.URL, ":")+1:])
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("connects to the resolved address for a matching host and port", func() {
+			resp, err := curl.ExecuteRequest(
+				curl.WithHost("gateway.invalid"),
+				curl.WithPort(port),
+				curl.WithResolve("gateway.invalid", port, "127.0.0.1"),
+			)
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(lastRequest.Host).To(Equal(fmt.Sprintf("gateway.invalid:%d", port)))
+		})
+
+		It("resolves other host and port pairs normally", func() {
+			resp, err := curl.ExecuteRequest(
+				curl.WithHost("gateway.invalid"),
+				curl.WithPort(port),
+				curl.WithResolve("gateway.invalid", port+1, "127.0.0.1"),
+				curl.WithResolve("other.invalid", port, "127.0.0.1"),
+			)
+			Expect(err).To(MatchError(ContainSubstring("lookup gateway.invalid")))
+			Expect(resp).To(BeNil())
+		})
+	})
+
+	Context("WithHTTP2", func() {
+
+		// newServer returns a started server that supports HTTP/2 over cleartext with prior knowledge, or via ALPN over TLS
+		newServer := func(tls bool) *httptest.Server {
+			s := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+			if tls {
+				s.EnableHTTP2 = true
+				s.StartTLS()
+			} else {
+				s.Config.Protocols = new(http.Protocols)
+				s.Config.Protocols.SetHTTP1(true)
+				s.Config.Protocols.SetUnencryptedHTTP2(true)
+				s.Start()
+			}
+			DeferCleanup(s.Close)
+			return s
+		}
+
+		DescribeTable("uses the expected protocol",
+			func(tls bool, expectedProto string, opts ...curl.Option) {
+				s := newServer(tls)
+				scheme := "http"
+				if tls {
+					scheme = "https"
+				}
+				resp, err := curl.ExecuteRequest(append([]curl.Option{
+					curl.WithScheme(scheme),
+					curl.WithHostPort(strings.TrimPrefix(s.URL, scheme+"://")),
+					curl.IgnoreServerCert(),
+				}, opts...)...)
+				Expect(err).NotTo(HaveOccurred())
+				defer resp.Body.Close()
+				Expect(resp.Proto).To(Equal(expectedProto))
+			},
+			Entry("cleartext by default", false, "HTTP/1.1"),
+			Entry("cleartext with prior knowledge", false, "HTTP/2.0", curl.WithHTTP2()),
+			Entry("TLS with HTTP/1.1 forced", true, "HTTP/1.1", curl.WithHTTP11()),
+			Entry("TLS negotiated via ALPN", true, "HTTP/2.0", curl.WithHTTP2()),
+		)
+	})
+
+	Context("Authorization", func() {
+
+		It("sets a basic auth header", func() {
+			resp, err := curl.ExecuteRequest(serverOpts(curl.WithBasicAuth("user", "pass"))...)
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			user, pass, ok := lastRequest.BasicAuth()
+			Expect(ok).To(BeTrue())
+			Expect(user).To(Equal("user"))
+			Expect(pass).To(Equal("pass"))
+		})
+
+		It("sets a bearer token header", func() {
+			resp, err := curl.ExecuteRequest(serverOpts(curl.WithBearerToken("token"))...)
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(lastRequest.Header.Values("Authorization")).To(ConsistOf("Bearer token"))
+		})
+
+		DescribeTable("uses the last configured Authorization header",
+			func(expected string, opts ...curl.Option) {
+				resp, err := curl.ExecuteRequest(serverOpts(opts...)...)
+				Expect(err).NotTo(HaveOccurred())
+				defer resp.Body.Close()
+				Expect(lastRequest.Header.Values("Authorization")).To(ConsistOf(expected))
+			},
+			Entry("header then bearer token", "Bearer token",
+				curl.WithHeader("authorization", "Custom value"), curl.WithBearerToken("token")),
+			Entry("bearer token then header", "Custom value",
+				curl.WithBearerToken("token"), curl.WithHeader("authorization", "Custom value")),
+			Entry("basic auth then bearer token", "Bearer token",
+				curl.WithBasicAuth("user", "pass"), curl.WithBearerToken("token")),
+			Entry("headers map then basic auth", "Basic dXNlcjpwYXNz",
+				curl.WithHeaders(map[string]string{"AUTHORIZATION": "Custom value"}), curl.WithBasicAuth("user", "pass")),
+		)
+	})
+
+	Context("WithRetries", func() {
+
+		var (
+			flakyServer *httptest.Server
+			// failures is the number of requests the flaky server will drop before responding
+			failures int32
+			// requests is the number of requests received by the flaky server
+			requests atomic.Int32
+		)
+
+		BeforeEach(func() {
+			failures = 0
+			requests.Store(0)
+			flakyServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if requests.Add(1) <= failures {
+					// drop the connection without a response
+					conn, _, err := w.(http.Hijacker).Hijack()
+					Expect(err).NotTo(HaveOccurred())
+					conn.Close()
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+		})
+
+		AfterEach(func() {
+			flakyServer.Close()
+		})
+
+		flakyServerOpts := func(opts ...curl.Option) []curl.Option {
+			return append([]curl.Option{curl.WithHostPort(strings.TrimPrefix(flakyServer.URL, "http://"))}, opts...)
+		}
+
+		It("retries connection failures until the request succeeds", func() {
+			failures = 2
+			resp, err := curl.ExecuteRequest(flakyServerOpts(curl.WithRetries(2, 0, 0))...)
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			Expect(curl.Attempts(resp)).To(Equal(3))
+		})
+
+		It("returns the error once retries are exhausted", func() {
+			failures = 3
+			resp, err := curl.ExecuteRequest(flakyServerOpts(curl.WithRetries(2, 0, 0))...)
+			Expect(err).To(MatchError(ContainSubstring("request failed after 3 attempts")))
+			Expect(resp).To(BeNil())
+			Expect(requests.Load()).To(BeEquivalentTo(3))
+		})
+
+		It("resends the body on each attempt", func() {
+			failures = 1
+			resp, err := curl.ExecuteRequest(flakyServerOpts(
+				curl.WithMethod(http.MethodPut),
+				curl.WithBody("payload"),
+				curl.WithRetries(1, 0, 0),
+			)...)
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(curl.Attempts(resp)).To(Equal(2))
+		})
+
+		It("does not retry non-idempotent methods", func() {
+			failures = 1
+			resp, err := curl.ExecuteRequest(flakyServerOpts(curl.WithPostBody(`{}`), curl.WithRetries(2, 0, 0))...)
+			Expect(err).To(HaveOccurred())
+			Expect(resp).To(BeNil())
+			Expect(requests.Load()).To(BeEquivalentTo(1))
+		})
+
+		It("records a single attempt when no retries are configured", func() {
+			resp, err := curl.ExecuteRequest(flakyServerOpts()...)
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(curl.Attempts(resp)).To(Equal(1))
+		})
+
+		Context("with transient responses", func() {
+
+			var statusServer *httptest.Server
+
+			BeforeEach(func() {
+				statusServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if requests.Add(1) <= failures {
+						w.WriteHeader(http.StatusServiceUnavailable)
+						return
+					}
+					w.WriteHeader(http.StatusOK)
+				}))
+			})
+
+			AfterEach(func() {
+				statusServer.Close()
+			})
+
+			statusServerOpts := func(opts ...curl.Option) []curl.Option {
+				return append([]curl.Option{curl.WithHostPort(strings.TrimPrefix(statusServer.URL, "http://"))}, opts...)
+			}
+
+			It("retries until the request succeeds", func() {
+				failures = 2
+				resp, err := curl.ExecuteRequest(statusServerOpts(curl.WithRetries(3, 0, 0), curl.WithRetryTransientResponses(true))...)
+				Expect(err).NotTo(HaveOccurred())
+				defer resp.Body.Close()
+				Expect(resp.StatusCode).To(Equal(http.StatusOK))
+				Expect(curl.Attempts(resp)).To(Equal(3))
+			})
+
+			It("returns the last response once retries are exhausted", func() {
+				failures = 3
+				resp, err := curl.ExecuteRequest(statusServerOpts(curl.WithRetries(2, 0, 0), curl.WithRetryTransientResponses(true))...)
+				Expect(err).NotTo(HaveOccurred())
+				defer resp.Body.Close()
+				Expect(resp.StatusCode).To(Equal(http.StatusServiceUnavailable))
+				Expect(curl.Attempts(resp)).To(Equal(3))
+			})
+
+			It("does not retry without retries configured", func() {
+				failures = 1
+				resp, err := curl.ExecuteRequest(statusServerOpts()...)
+				Expect(err).NotTo(HaveOccurred())
+				defer resp.Body.Close()
+				Expect(resp.StatusCode).To(Equal(http.StatusServiceUnavailable))
+				Expect(requests.Load()).To(BeEquivalentTo(1))
+			})
+
+			It("does not retry unless transient responses are enabled", func() {
+				failures = 1
+				resp, err := curl.ExecuteRequest(statusServerOpts(curl.WithRetries(2, 0, 0))...)
+				Expect(err).NotTo(HaveOccurred())
+				defer resp.Body.Close()
+				Expect(resp.StatusCode).To(Equal(http.StatusServiceUnavailable))
+				Expect(curl.Attempts(resp)).To(Equal(1))
+			})
+		})
+
+		It("only retries refused connections when enabled", func() {
+			addr := strings.TrimPrefix(flakyServer.URL, "http://")
+			flakyServer.Close()
+
+			_, err := curl.ExecuteRequest(curl.WithHostPort(addr), curl.WithRetries(1, 0, 0))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).NotTo(ContainSubstring("attempts"))
+
+			_, err = curl.ExecuteRequest(curl.WithHostPort(addr), curl.WithRetries(1, 0, 0), curl.WithRetryConnectionRefused(true))
+			Expect(err).To(MatchError(ContainSubstring("request failed after 2 attempts")))
+		})
+	})
+
+	Context("WithClientCertPEM and WithCACert", func() {
+
+		var (
+			tlsServer *httptest.Server
+			// caPEM is the PEM encoded certificate of the TLS server
+			caPEM []byte
+			// clientCertPEM and clientKeyPEM are trusted by the TLS server
+			clientCertPEM, clientKeyPEM []byte
+			// peerCertificates is the set of client certificates presented in the most recent request
+			peerCertificates []*x509.Certificate
+		)
+
+		BeforeEach(func() {
+			peerCertificates = nil
+			clientCertPEM, clientKeyPEM = generateCertificate()
+
+			clientCAs := x509.NewCertPool()
+			Expect(clientCAs.AppendCertsFromPEM(clientCertPEM)).To(BeTrue())
+
+			tlsServer = httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				peerCertificates = r.TLS.PeerCertificates
+				w.WriteHeader(http.StatusOK)
+			}))
+			tlsServer.TLS = &tls.Config{
+				ClientAuth: tls.VerifyClientCertIfGiven,
+				ClientCAs:  clientCAs,
+			}
+			tlsServer.StartTLS()
+			caPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: tlsServer.Certificate().Raw})
+		})
+
+		AfterEach(func() {
+			tlsServer.Close()
+		})
+
+		tlsServerOpts := func(opts ...curl.Option) []curl.Option {
+			return append([]curl.Option{
+				curl.WithScheme("https"),
+				curl.WithHostPort(strings.TrimPrefix(tlsServer.URL, "https://")),
+			}, opts...)
+		}
+
+		It("verifies the server using the provided CA", func() {
+			resp, err := curl.ExecuteRequest(tlsServerOpts(curl.WithCACert(caPEM))...)
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			Expect(peerCertificates).To(BeEmpty())
+		})
+
+		It("fails to verify the server without the CA", func() {
+			otherCA, _ := generateCertificate()
+			resp, err := curl.ExecuteRequest(tlsServerOpts(curl.WithCACert(otherCA))...)
+			Expect(err).To(MatchError(ContainSubstring("certificate signed by unknown authority")))
+			Expect(resp).To(BeNil())
+		})
+
+		It("skips verification of an untrusted server when ignoring the server cert", func() {
+			resp, err := curl.ExecuteRequest(tlsServerOpts(curl.IgnoreServerCert())...)
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		})
+
+		It("skips hostname verification when ignoring the server cert with a CA", func() {
+			// the test server certificate is not valid for localhost
+			localhostOpts := func(opts ...curl.Option) []curl.Option {
+				return append(tlsServerOpts(curl.WithHost("localhost"), curl.WithCACert(caPEM)), opts...)
+			}
+
+			_, err := curl.ExecuteRequest(localhostOpts()...)
+			Expect(err).To(MatchError(ContainSubstring("certificate is valid for")))
+
+			resp, err := curl.ExecuteRequest(localhostOpts(curl.IgnoreServerCert())...)
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		})
+
+		It("presents the client certificate", func() {
+			resp, err := curl.ExecuteRequest(tlsServerOpts(
+				curl.WithCACert(caPEM),
+				curl.WithClientCertPEM(clientCertPEM, clientKeyPEM),
+			)...)
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(peerCertificates).To(HaveLen(1))
+			Expect(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: peerCertificates[0].Raw})).To(Equal(clientCertPEM))
+		})
+
+		It("returns an error if the client certificate and key do not match", func() {
+			_, otherKeyPEM := generateCertificate()
+			resp, err := curl.ExecuteRequest(tlsServerOpts(
+				curl.WithCACert(caPEM),
+				curl.WithClientCertPEM(clientCertPEM, otherKeyPEM),
+			)...)
+			Expect(err).To(MatchError(ContainSubstring("failed to load client certificate and key")))
+			Expect(resp).To(BeNil())
+		})
+
+		It("returns an error if the CA is not valid PEM", func() {
+			resp, err := curl.ExecuteRequest(tlsServerOpts(curl.WithCACert([]byte("not a certificate")))...)
+			Expect(err).To(MatchError(ContainSubstring("failed to load CA certificate")))
+			Expect(resp).To(BeNil())
+		})
+	})
+})
+
+// countingTransport counts the requests executed by the wrapped RoundTripper
+type countingTransport struct {
+	http.RoundTripper
+	requests int
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests++
+	return t.RoundTripper.RoundTrip(req)
+}
+
+// generateCertificate returns a PEM encoded self-signed certificate and private key
+func generateCertificate() (certPEM, keyPEM []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).NotTo(HaveOccurred())
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		IsCA:         true,
+
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	Expect(err).NotTo(HaveOccurred())
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	Expect(err).NotTo(HaveOccurred())
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
@@ -1,10 +1,19 @@
 package curl
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // TLS version constants for use with WithTLSVersion and WithTLSMaxVersion
@@ -27,6 +36,12 @@ const (
 	CurvePrime256v1 = "prime256v1"
 )
 
+// Body encoding constants for use with WithCompressedBody
+const (
+	EncodingGzip    = "gzip"
+	EncodingDeflate = "deflate"
+)
+
 // Option represents an option for a curl request.
 type Option func(config *requestConfig)
 
@@ -39,6 +54,8 @@ func VerboseOutput() Option {
 }
 
 // IgnoreServerCert returns the Option to ignore the server certificate in the curl request
+// For native requests executed via ExecuteRequest, this sets InsecureSkipVerify on the TLS config,
+// which disables verification of both the certificate chain and the hostname, even if WithCACert is set.
 // https://curl.se/docs/manpage.html#-k
 func IgnoreServerCert() Option {
 	return func(config *requestConfig) {
@@ -63,6 +80,7 @@ func WithHeadersOnly() Option {
 }
 
 // WithConnectionTimeout returns the Option to set a connection timeout on the curl request
+// The timeout covers the whole request, including establishing the connection, unless WithConnectTimeout is set.
 // https://curl.se/docs/manpage.html#--connect-timeout
 // https://curl.se/docs/manpage.html#-m
 func WithConnectionTimeout(seconds int) Option {
@@ -71,11 +89,50 @@ func WithConnectionTimeout(seconds int) Option {
 	}
 }
 
+// WithConnectTimeout returns the Option to set a timeout for establishing the connection of the curl request
+// For native requests, this is the timeout of the dialer, which covers name resolution and the TCP connect,
+// while the time allowed for the rest of the request is still governed by WithConnectionTimeout.
+// It takes precedence over WithConnectionTimeout for the connection phase.
+// https://curl.se/docs/manpage.html#--connect-timeout
+func WithConnectTimeout(timeout time.Duration) Option {
+	return func(config *requestConfig) {
+		if timeout < 0 {
+			config.addError(fmt.Errorf("invalid connect timeout %s: must not be negative", timeout))
+			return
+		}
+		config.connectTimeout = timeout
+	}
+}
+
+// WithFollowRedirects returns the Option to configure whether redirects are followed
+// By default they are not, and the redirect response itself (e.g. a 301 and its Location header) is returned.
+// https://curl.se/docs/manpage.html#-L
+func WithFollowRedirects(follow bool) Option {
+	return func(config *requestConfig) {
+		config.followRedirects = follow
+	}
+}
+
+// WithMaxRedirects returns the Option to limit the number of redirects followed when WithFollowRedirects is enabled
+// Exceeding the limit is an error, as with curl. Native requests otherwise follow at most 10 redirects.
+// https://curl.se/docs/manpage.html#--max-redirs
+func WithMaxRedirects(n int) Option {
+	return func(config *requestConfig) {
+		if n < 0 {
+			config.addError(fmt.Errorf("invalid max redirects %d: must not be negative", n))
+			return
+		}
+		config.maxRedirects = n
+	}
+}
+
 // WithMethod returns the Option to set the method for the curl request
+// When executing a native request, the method must be one of the standard net/http methods
 // https://curl.se/docs/manpage.html#-X
 func WithMethod(method string) Option {
 	return func(config *requestConfig) {
 		config.method = method
+		config.methodSet = true
 	}
 }
 
@@ -118,6 +175,30 @@ func WithSni(sni string) Option {
 	}
 }
 
+// WithResolve returns the Option to connect to the provided address, instead of resolving the provided host and port
+// Other host and port pairs are resolved normally. Multiple calls accumulate, with the last one winning for a given host and port.
+// https://curl.se/docs/manpage.html#--resolve
+func WithResolve(host string, port int, addr string) Option {
+	return func(config *requestConfig) {
+		if config.resolve == nil {
+			config.resolve = make(map[string]string)
+		}
+		config.resolve[net.JoinHostPort(host, strconv.Itoa(port))] = addr
+	}
+}
+
+// WithUnixSocket returns the Option to connect to the unix socket at the provided path, instead of over TCP
+// This bypasses the addressing of WithHost, WithPort and WithResolve: every connection is made to the socket,
+// while the host and port are still used to build the request URL, and so the Host header, and the path is sent as is.
+// It is mainly useful to reach admin endpoints exposed on a socket made available through a hostPath mount
+// or forwarded to a local file in test environments.
+// https://curl.se/docs/manpage.html#--unix-socket
+func WithUnixSocket(path string) Option {
+	return func(config *requestConfig) {
+		config.unixSocket = path
+	}
+}
+
 // WithCaFile returns the Option to configure the certificate file used to verify the peer
 // https://curl.se/docs/manpage.html#--cacert
 func WithCaFile(caFile string) Option {
@@ -136,13 +217,40 @@ func WithPath(path string) Option {
 }
 
 // WithQueryParameters returns the Option to configure the query parameters of the curl request
+// It replaces any query parameters previously configured
 func WithQueryParameters(parameters map[string]string) Option {
 	return func(config *requestConfig) {
-		config.queryParameters = parameters
+		config.queryParameters = url.Values{}
+		for k, v := range parameters {
+			config.queryParameters.Set(k, v)
+		}
+	}
+}
+
+// WithQueryParam returns the Option to add a query parameter to the curl request
+// Values are URL encoded, and multiple calls with the same key add multiple values for that key
+func WithQueryParam(key, value string) Option {
+	return func(config *requestConfig) {
+		if config.queryParameters == nil {
+			config.queryParameters = url.Values{}
+		}
+		config.queryParameters.Add(key, value)
+	}
+}
+
+// WithRawQuery returns the Option to configure a pre-encoded query string for the curl request
+// The query is sent as is, without a leading `?`, ahead of any parameters configured via WithQueryParam.
+// It replaces any raw query previously configured
+func WithRawQuery(rawQuery string) Option {
+	return func(config *requestConfig) {
+		config.rawQuery = strings.TrimPrefix(rawQuery, "?")
 	}
 }
 
 // WithRetries returns the Option to configure the retries for the curl request
+// The curl binary retries connection-level failures and transient responses (408, 429, 500, 502, 503 and 504).
+// When executing a native request, only connection-level failures of idempotent methods are retried,
+// unless WithRetryTransientResponses is also set
 // https://curl.se/docs/manpage.html#--retry
 // https://curl.se/docs/manpage.html#--retry-delay
 // https://curl.se/docs/manpage.html#--retry-max-time
@@ -163,6 +271,15 @@ func WithRetryConnectionRefused(retryConnectionRefused bool) Option {
 	}
 }
 
+// WithRetryTransientResponses returns the Option to also retry transient responses (408, 429, 500, 502, 503 and 504)
+// when executing a native request with retries configured via WithRetries.
+// The curl binary always retries transient responses, so this is only used by ExecuteRequest
+func WithRetryTransientResponses(retryTransientResponses bool) Option {
+	return func(config *requestConfig) {
+		config.retryTransientResponses = retryTransientResponses
+	}
+}
+
 // WithoutRetries returns the Option to disable retries for the curl request
 func WithoutRetries() Option {
 	return func(config *requestConfig) {
@@ -188,6 +305,54 @@ func WithBody(body string) Option {
 	}
 }
 
+// WithBodyReader returns the Option to configure the body for a curl request from an io.Reader
+// The reader is consumed in full when the option is applied, so the body can be resent if the request is retried.
+// Any error reading the body is returned by ExecuteRequest.
+// https://curl.se/docs/manpage.html#-d
+func WithBodyReader(body io.Reader) Option {
+	return func(config *requestConfig) {
+		b, err := io.ReadAll(body)
+		if err != nil {
+			config.addError(fmt.Errorf("failed to read request body: %w", err))
+			return
+		}
+		WithBody(string(b))(config)
+	}
+}
+
+// WithJSONBody returns the Option to configure the body for a curl request with the JSON encoding of v
+// The Content-Type header is set to application/json, unless a Content-Type has already been configured.
+// Any error marshalling v is returned by ExecuteRequest.
+func WithJSONBody(v any) Option {
+	return func(config *requestConfig) {
+		b, err := json.Marshal(v)
+		if err != nil {
+			config.addError(fmt.Errorf("failed to marshal JSON request body: %w", err))
+			return
+		}
+		WithBody(string(b))(config)
+		if !config.hasHeader("Content-Type") {
+			WithContentType("application/json")(config)
+		}
+	}
+}
+
+// WithCompressedBody returns the Option to compress the request body with encoding, either gzip or deflate,
+// and set the Content-Encoding header accordingly. The body is compressed when the request is executed,
+// so this composes with WithBody, WithBodyReader and WithJSONBody in any order.
+// Any unsupported encoding is returned by ExecuteRequest.
+// This is not supported when building curl args, which always send the body uncompressed.
+func WithCompressedBody(encoding string) Option {
+	return func(config *requestConfig) {
+		switch encoding {
+		case EncodingGzip, EncodingDeflate:
+			config.bodyEncoding = encoding
+		default:
+			config.addError(fmt.Errorf("unsupported body encoding %q: must be %q or %q", encoding, EncodingGzip, EncodingDeflate))
+		}
+	}
+}
+
 // WithContentType returns the Option to configure the Content-Type header for the curl request
 func WithContentType(contentType string) Option {
 	return func(config *requestConfig) {
@@ -208,7 +373,8 @@ func WithIgnoreBody() Option {
 	}
 }
 
-// WithHeader returns the Option to configure a basic auth header for the curl request
+// WithBasicAuth returns the Option to configure a basic auth header for the curl request
+// It replaces any Authorization header previously configured
 func WithBasicAuth(username string, password string) Option {
 	auth := username + ":" + password
 	basicAuth := base64.StdEncoding.EncodeToString([]byte(auth))
@@ -217,10 +383,20 @@ func WithBasicAuth(username string, password string) Option {
 	}
 }
 
+// WithBearerToken returns the Option to configure a bearer token auth header for the curl request
+// It replaces any Authorization header previously configured
+func WithBearerToken(token string) Option {
+	return func(config *requestConfig) {
+		WithHeader("Authorization", "Bearer "+token)(config)
+	}
+}
+
 // WithHeader returns the Option to configure a header for the curl request
+// Header names are case-insensitive, so any value previously configured for the same header is replaced
 // https://curl.se/docs/manpage.html#-H
 func WithHeader(key, value string) Option {
 	return func(config *requestConfig) {
+		config.deleteHeader(key)
 		config.headers[key] = []string{value}
 	}
 }
@@ -232,6 +408,7 @@ func WithHeaders(headers map[string]string) Option {
 			config.headers = make(map[string][]string)
 		}
 		for h, v := range headers {
+			config.deleteHeader(h)
 			config.headers[h] = []string{v}
 		}
 	}
@@ -255,6 +432,7 @@ func WithScheme(scheme string) Option {
 // WithArgs allows developers to append arbitrary args to the curl request
 // This should mainly be used for debugging purposes. If there is an argument that the current Option
 // set doesn't yet support, it should be added explicitly, to make it easier for developers to utilize
+// The args are only used when building curl args, and are ignored by ExecuteRequest.
 func WithArgs(args []string) Option {
 	return func(config *requestConfig) {
 		config.additionalArgs = args
@@ -267,6 +445,20 @@ func WithCookie(cookie string) Option {
 	}
 }
 
+// WithNamedCookie returns the Option to send a cookie with the curl request
+// Calling it multiple times sends multiple cookies, along with the one configured via WithCookie.
+// To send cookies set by earlier responses, use a client from BuildClientWithCookieJar.
+// https://curl.se/docs/manpage.html#-b
+func WithNamedCookie(name, value string) Option {
+	return func(config *requestConfig) {
+		config.cookies = append(config.cookies, &http.Cookie{Name: name, Value: value})
+	}
+}
+
+// WithCookieJar returns the Option to write the cookies received by the curl request to the provided file
+// It is only used when building curl args. Native requests can keep cookies across requests with a
+// client from BuildClientWithCookieJar instead.
+// https://curl.se/docs/manpage.html#-c
 func WithCookieJar(cookieJar string) Option {
 	return func(config *requestConfig) {
 		config.cookieJar = cookieJar
@@ -288,6 +480,8 @@ func WithHTTP11() Option {
 }
 
 // WithHTTP2 returns the Option to force HTTP/2 protocol
+// For native requests executed via ExecuteRequest, HTTP/2 is negotiated via ALPN over TLS,
+// and used with prior knowledge (h2c) over cleartext. The negotiated protocol is available as the Proto of the response.
 // https://curl.se/docs/manpage.html#--http2
 func WithHTTP2() Option {
 	return func(config *requestConfig) {
@@ -339,3 +533,61 @@ func WithClientCert(certFile, keyFile string) Option {
 		config.clientKey = keyFile
 	}
 }
+
+// WithClientCertPEM returns the Option to configure a PEM encoded client certificate and key for mTLS
+// The certificate is presented during the TLS handshake of native requests executed via ExecuteRequest,
+// and is not supported when building curl args. Use WithClientCert to reference files on the curl host instead.
+func WithClientCertPEM(certPEM, keyPEM []byte) Option {
+	return func(config *requestConfig) {
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			config.addError(fmt.Errorf("failed to load client certificate and key: %w", err))
+			return
+		}
+		config.clientCertificates = []tls.Certificate{cert}
+	}
+}
+
+// WithCACert returns the Option to configure the PEM encoded CA certificates used to verify the peer
+// The certificates replace the system root pool for native requests executed via ExecuteRequest,
+// and are not supported when building curl args. Use WithCaFile to reference a file on the curl host instead.
+func WithCACert(caPEM []byte) Option {
+	return func(config *requestConfig) {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			config.addError(errors.New("failed to load CA certificate: no valid PEM certificates found"))
+			return
+		}
+		config.rootCAs = pool
+	}
+}
+
+// WithDisableAutoDecompress returns the Option to disable the transparent decompression of responses by native requests
+// By default, net/http requests a gzip response and decompresses it, removing the Content-Encoding header.
+// With this option the request still advertises Accept-Encoding: gzip, unless an Accept-Encoding header is configured,
+// but the response is returned as sent, so its Content-Encoding header and compressed body can be asserted on.
+// Curl never decompresses responses unless --compressed is set, so this has no effect when building curl args.
+func WithDisableAutoDecompress() Option {
+	return func(config *requestConfig) {
+		config.disableAutoDecompress = true
+	}
+}
+
+// WithConnectionPool returns the Option to configure the idle connection pool of the transport used by native requests
+// maxIdle and maxIdlePerHost cap the number of idle (keep-alive) connections kept across all hosts and per host,
+// and idleTimeout is how long an idle connection is kept before being closed. A zero value leaves the net/http default.
+// Since ExecuteRequest creates a new transport for each call, connections are only reused across requests
+// when the transport returned by BuildTransport is shared, e.g. via ExecuteRequestWithClient.
+// This is not supported when building curl args.
+func WithConnectionPool(maxIdle, maxIdlePerHost int, idleTimeout time.Duration) Option {
+	return func(config *requestConfig) {
+		if maxIdle < 0 || maxIdlePerHost < 0 || idleTimeout < 0 {
+			config.addError(fmt.Errorf("invalid connection pool: maxIdle (%d), maxIdlePerHost (%d) and idleTimeout (%s) must not be negative",
+				maxIdle, maxIdlePerHost, idleTimeout))
+			return
+		}
+		config.maxIdleConns = maxIdle
+		config.maxIdleConnsPerHost = maxIdlePerHost
+		config.idleConnTimeout = idleTimeout
+	}
+}
@@ -0,0 +1,397 @@
+package curl
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseCommand parses a curl command line, such as one copied from documentation, into the equivalent set of Option
+// The command is split into arguments like a POSIX shell would, and the leading `curl` is optional.
+// Any flag that is not supported is an error, rather than being ignored, so that a command is never replayed
+// differently from how curl would execute it.
+//
+// Example:
+//
+//	opts, err := ParseCommand(`curl -X POST -H "Content-Type: application/json" -d '{"a":1}' http://localhost:8080/post`)
+//	resp, err := ExecuteRequest(opts...)
+func ParseCommand(cmd string) ([]Option, error) {
+	args, err := splitCommand(cmd)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) > 0 && args[0] == "curl" {
+		args = args[1:]
+	}
+	return ParseArgs(args)
+}
+
+// ParseArgs parses curl arguments into the equivalent set of Option
+// It supports the flags rendered by BuildArgs, so ParseArgs(BuildArgs(opts...)) produces options
+// which render to the same arguments, as well as their common aliases (e.g. --header for -H).
+func ParseArgs(args []string) ([]Option, error) {
+	p := &argParser{retryDelay: -1}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") {
+			if err := p.parseURL(arg); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		flag, value, hasValue := splitShortFlag(arg)
+		if !valueFlags[flag] {
+			if expanded, ok := expandShortFlags(arg); ok {
+				for _, f := range expanded {
+					if err := p.parseFlag(f, ""); err != nil {
+						return nil, err
+					}
+				}
+				continue
+			}
+			if err := p.parseFlag(arg, ""); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if !hasValue {
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("curl flag %s requires a value", flag)
+			}
+			i++
+			value = args[i]
+		}
+		if err := p.parseFlag(flag, value); err != nil {
+			return nil, err
+		}
+	}
+	return p.build()
+}
+
+// valueFlags are the supported flags which take a value
+var valueFlags = map[string]bool{
+	"-X": true, "--request": true,
+	"-H": true, "--header": true,
+	"-d": true, "--data": true, "--data-binary": true, "--data-raw": true,
+	"-u": true, "--user": true,
+	"-b": true, "--cookie": true,
+	"-c": true, "--cookie-jar": true,
+	"-m": true, "--max-time": true,
+	"--resolve":         true,
+	"--unix-socket":     true,
+	"--cacert":          true,
+	"--connect-timeout": true,
+	"--max-redirs":      true,
+	"--retry":           true,
+	"--retry-delay":     true,
+	"--retry-max-time":  true,
+}
+
+// splitShortFlag splits a short flag with an attached value, such as -XPOST, into the flag and its value
+func splitShortFlag(arg string) (flag, value string, hasValue bool) {
+	if strings.HasPrefix(arg, "--") || len(arg) <= 2 || !valueFlags[arg[:2]] {
+		return arg, "", false
+	}
+	return arg[:2], arg[2:], true
+}
+
+// expandShortFlags expands combined short boolean flags, such as -sk, into the individual flags
+func expandShortFlags(arg string) ([]string, bool) {
+	if strings.HasPrefix(arg, "--") || len(arg) <= 2 {
+		return nil, false
+	}
+	flags := make([]string, 0, len(arg)-1)
+	for _, c := range arg[1:] {
+		flag := "-" + string(c)
+		if valueFlags[flag] {
+			return nil, false
+		}
+		flags = append(flags, flag)
+	}
+	return flags, true
+}
+
+// argParser accumulates the options parsed from curl arguments
+type argParser struct {
+	options []Option
+
+	// headers are grouped case-insensitively, in the order they were first seen, so repeated headers are all sent
+	headerNames []string
+	headers     map[string][]string
+
+	retrySet     bool
+	retry        int
+	retryDelay   int
+	retryMaxTime int
+
+	url string
+}
+
+func (p *argParser) parseFlag(flag, value string) error {
+	switch flag {
+	case "-v", "--verbose":
+		p.options = append(p.options, VerboseOutput())
+	case "-s", "--silent":
+		p.options = append(p.options, Silent())
+	case "-k", "--insecure":
+		p.options = append(p.options, IgnoreServerCert())
+	case "-I", "--head":
+		p.options = append(p.options, WithHeadersOnly())
+	case "-L", "--location":
+		p.options = append(p.options, WithFollowRedirects(true))
+	case "--http1.1":
+		p.options = append(p.options, WithHTTP11())
+	case "--http2":
+		p.options = append(p.options, WithHTTP2())
+	case "--haproxy-protocol":
+		p.options = append(p.options, WithProxyProto())
+	case "--retry-connrefused":
+		p.options = append(p.options, WithRetryConnectionRefused(true))
+
+	case "-X", "--request":
+		p.options = append(p.options, WithMethod(value))
+	case "-H", "--header":
+		return p.parseHeader(value)
+	case "-d", "--data", "--data-binary", "--data-raw":
+		if flag != "--data-raw" && strings.HasPrefix(value, "@") {
+			return fmt.Errorf("curl flag %s: reading the body from a file is not supported", flag)
+		}
+		p.options = append(p.options, WithBody(value))
+	case "-u", "--user":
+		username, password, ok := strings.Cut(value, ":")
+		if !ok {
+			return fmt.Errorf("curl flag %s: expected user:password, got %q", flag, value)
+		}
+		p.options = append(p.options, WithBasicAuth(username, password))
+	case "-b", "--cookie":
+		return p.parseCookies(flag, value)
+	case "-c", "--cookie-jar":
+		p.options = append(p.options, WithCookieJar(value))
+	case "--resolve":
+		return p.parseResolve(value)
+	case "--unix-socket":
+		p.options = append(p.options, WithUnixSocket(value))
+	case "--cacert":
+		p.options = append(p.options, WithCaFile(value))
+	case "--connect-timeout":
+		seconds, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("curl flag %s: invalid number of seconds %q", flag, value)
+		}
+		p.options = append(p.options, WithConnectTimeout(time.Duration(seconds*float64(time.Second))))
+	case "-m", "--max-time":
+		seconds, err := parseIntFlag(flag, value)
+		if err != nil {
+			return err
+		}
+		p.options = append(p.options, WithConnectionTimeout(seconds))
+	case "--max-redirs":
+		n, err := parseIntFlag(flag, value)
+		if err != nil {
+			return err
+		}
+		p.options = append(p.options, WithMaxRedirects(n))
+	case "--retry", "--retry-delay", "--retry-max-time":
+		n, err := parseIntFlag(flag, value)
+		if err != nil {
+			return err
+		}
+		p.retrySet = true
+		switch flag {
+		case "--retry":
+			p.retry = n
+		case "--retry-delay":
+			p.retryDelay = n
+		default:
+			p.retryMaxTime = n
+		}
+
+	default:
+		return fmt.Errorf("unsupported curl flag %s", flag)
+	}
+	return nil
+}
+
+func parseIntFlag(flag, value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("curl flag %s: invalid integer %q", flag, value)
+	}
+	return n, nil
+}
+
+func (p *argParser) parseHeader(value string) error {
+	name, headerValue, ok := strings.Cut(value, ":")
+	if !ok || strings.TrimSpace(name) == "" {
+		return fmt.Errorf("curl flag -H: expected \"Name: value\", got %q", value)
+	}
+	name = strings.TrimSpace(name)
+	headerValue = strings.TrimSpace(headerValue)
+
+	if p.headers == nil {
+		p.headers = make(map[string][]string)
+	}
+	for _, existing := range p.headerNames {
+		if strings.EqualFold(existing, name) {
+			p.headers[existing] = append(p.headers[existing], headerValue)
+			return nil
+		}
+	}
+	p.headerNames = append(p.headerNames, name)
+	p.headers[name] = []string{headerValue}
+	return nil
+}
+
+func (p *argParser) parseCookies(flag, value string) error {
+	for pair := range strings.SplitSeq(value, ";") {
+		name, cookieValue, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			// curl treats a value without a `=` as a file to read cookies from
+			return fmt.Errorf("curl flag %s: reading cookies from a file is not supported", flag)
+		}
+		p.options = append(p.options, WithNamedCookie(name, cookieValue))
+	}
+	return nil
+}
+
+func (p *argParser) parseResolve(value string) error {
+	// host:port:addr, where addr may itself be an IPv6 address containing colons
+	host, rest, ok := strings.Cut(value, ":")
+	portStr, addr, ok2 := strings.Cut(rest, ":")
+	port, err := strconv.Atoi(portStr)
+	if !ok || !ok2 || err != nil || addr == "" {
+		return fmt.Errorf("curl flag --resolve: expected host:port:addr, got %q", value)
+	}
+	p.options = append(p.options, WithResolve(host, port, strings.Trim(addr, "[]")))
+	return nil
+}
+
+func (p *argParser) parseURL(value string) error {
+	if p.url != "" {
+		return fmt.Errorf("multiple URLs are not supported: %q and %q", p.url, value)
+	}
+	p.url = value
+	return nil
+}
+
+// build returns the parsed options, with the URL applied last
+func (p *argParser) build() ([]Option, error) {
+	if p.url == "" {
+		return nil, errors.New("curl command has no URL")
+	}
+
+	options := p.options
+	for _, name := range p.headerNames {
+		options = append(options, WithMultiHeader(name, p.headers[name]))
+	}
+	if p.retrySet {
+		options = append(options, WithRetries(p.retry, p.retryDelay, p.retryMaxTime))
+	}
+
+	rawURL := p.url
+	if !strings.Contains(rawURL, "://") {
+		// like curl, default to http when the URL has no scheme
+		rawURL = "http://" + rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL %q: %w", p.url, err)
+	}
+	if u.Hostname() == "" {
+		return nil, fmt.Errorf("invalid URL %q: missing host", p.url)
+	}
+
+	port := 80
+	if u.Scheme == "https" {
+		port = 443
+	}
+	if u.Port() != "" {
+		port, err = strconv.Atoi(u.Port())
+		if err != nil {
+			return nil, fmt.Errorf("invalid URL %q: invalid port", p.url)
+		}
+	}
+	host := u.Hostname()
+	if strings.Contains(host, ":") {
+		// IPv6 addresses are rendered into the URL as is, so keep their brackets
+		host = "[" + host + "]"
+	}
+
+	return append(options,
+		WithScheme(u.Scheme),
+		WithHost(host),
+		WithPort(port),
+		WithPath(u.RequestURI()),
+	), nil
+}
+
+// splitCommand splits a command line into arguments, following POSIX shell quoting rules:
+// single quotes preserve their content, double quotes allow backslash escapes of ", \, $, ` and newlines,
+// an unquoted backslash escapes the following character, and a backslash-newline continues the line
+func splitCommand(cmd string) ([]string, error) {
+	var (
+		args    []string
+		current strings.Builder
+		inArg   bool
+	)
+	runes := []rune(cmd)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '\'':
+			inArg = true
+			end := i + 1
+			for end < len(runes) && runes[end] != '\'' {
+				end++
+			}
+			if end >= len(runes) {
+				return nil, errors.New("unterminated single quote in curl command")
+			}
+			current.WriteString(string(runes[i+1 : end]))
+			i = end
+		case r == '"':
+			inArg = true
+			i++
+			for ; i < len(runes) && runes[i] != '"'; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) && strings.ContainsRune("\"\\$`\n", runes[i+1]) {
+					i++
+					if runes[i] == '\n' {
+						continue
+					}
+				}
+				current.WriteRune(runes[i])
+			}
+			if i >= len(runes) {
+				return nil, errors.New("unterminated double quote in curl command")
+			}
+		case r == '\\':
+			if i+1 >= len(runes) {
+				return nil, errors.New("trailing backslash in curl command")
+			}
+			i++
+			if runes[i] == '\n' {
+				continue
+			}
+			inArg = true
+			current.WriteRune(runes[i])
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			inArg = true
+			current.WriteRune(r)
+		}
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
@@ -0,0 +1,140 @@
+package curl_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/utils/requestutils/curl"
+)
+
+var _ = Describe("ParseCommand", func() {
+
+	DescribeTable("parses the command into the equivalent options",
+		func(cmd string, expected ...curl.Option) {
+			opts, err := curl.ParseCommand(cmd)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(curl.BuildArgs(opts...)).To(ConsistOf(curl.BuildArgs(expected...)))
+		},
+		Entry("url only",
+			"curl http://example.com",
+			curl.WithHost("example.com"), curl.WithPort(80),
+		),
+		Entry("url without a scheme",
+			"curl example.com:8080/get",
+			curl.WithHost("example.com"), curl.WithPort(8080), curl.WithPath("get"),
+		),
+		Entry("https url with a query",
+			"curl https://example.com/get?a=b",
+			curl.WithScheme("https"), curl.WithHost("example.com"), curl.WithPort(443), curl.WithPath("get?a=b"),
+		),
+		Entry("method, headers and body",
+			`curl -X PUT -H "Content-Type: application/json" --header 'x-test:value' -d '{"a": 1}' http://example.com/put`,
+			curl.WithMethod("PUT"), curl.WithContentType("application/json"), curl.WithHeader("x-test", "value"),
+			curl.WithBody(`{"a": 1}`), curl.WithHost("example.com"), curl.WithPort(80), curl.WithPath("put"),
+		),
+		Entry("repeated headers",
+			"curl -H 'x-test: a' -H 'X-Test: b' http://example.com",
+			curl.WithMultiHeader("x-test", []string{"a", "b"}), curl.WithHost("example.com"), curl.WithPort(80),
+		),
+		Entry("attached short flag value",
+			"curl -XDELETE http://example.com",
+			curl.WithMethod("DELETE"), curl.WithHost("example.com"), curl.WithPort(80),
+		),
+		Entry("combined short flags",
+			"curl -skv https://example.com",
+			curl.Silent(), curl.IgnoreServerCert(), curl.VerboseOutput(),
+			curl.WithScheme("https"), curl.WithHost("example.com"), curl.WithPort(443),
+		),
+		Entry("basic auth",
+			"curl -u user:pass http://example.com",
+			curl.WithBasicAuth("user", "pass"), curl.WithHost("example.com"), curl.WithPort(80),
+		),
+		Entry("resolve",
+			"curl --resolve example.com:80:127.0.0.1 http://example.com",
+			curl.WithResolve("example.com", 80, "127.0.0.1"), curl.WithHost("example.com"), curl.WithPort(80),
+		),
+		Entry("cookies",
+			"curl -b 'a=1; b=2' http://example.com",
+			curl.WithNamedCookie("a", "1"), curl.WithNamedCookie("b", "2"), curl.WithHost("example.com"), curl.WithPort(80),
+		),
+		Entry("line continuations",
+			"curl \\\n  -X POST \\\n  http://example.com",
+			curl.WithMethod("POST"), curl.WithHost("example.com"), curl.WithPort(80),
+		),
+	)
+
+	DescribeTable("returns an error",
+		func(cmd string, expectedErr string) {
+			_, err := curl.ParseCommand(cmd)
+			Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+		},
+		Entry("for an unknown flag", "curl --compressed http://example.com", "unsupported curl flag --compressed"),
+		Entry("for an unknown combined short flag", "curl -sS http://example.com", "unsupported curl flag -S"),
+		Entry("for a missing value", "curl http://example.com -H", "curl flag -H requires a value"),
+		Entry("for a missing URL", "curl -v", "curl command has no URL"),
+		Entry("for multiple URLs", "curl http://a.com http://b.com", "multiple URLs are not supported"),
+		Entry("for a body read from a file", "curl -d @body.json http://example.com", "reading the body from a file is not supported"),
+		Entry("for cookies read from a file", "curl -b cookies.txt http://example.com", "reading cookies from a file is not supported"),
+		Entry("for an invalid header", "curl -H novalue http://example.com", "expected \"Name: value\""),
+		Entry("for an unterminated quote", `curl -H "x-test: a http://example.com`, "unterminated double quote"),
+	)
+
+	DescribeTable("round-trips the args built from options",
+		func(opts ...curl.Option) {
+			args := curl.BuildArgs(opts...)
+			parsed, err := curl.ParseArgs(args)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(curl.BuildArgs(parsed...)).To(Equal(args))
+		},
+		Entry("defaults"),
+		Entry("flags",
+			curl.VerboseOutput(), curl.Silent(), curl.IgnoreServerCert(), curl.WithHeadersOnly(),
+			curl.WithFollowRedirects(true), curl.WithMaxRedirects(3), curl.WithHTTP2(),
+		),
+		Entry("request",
+			curl.WithMethod("POST"), curl.WithHostHeader("example.com"), curl.WithBody("body"),
+			curl.WithHostPort("10.0.0.1:8443"), curl.WithScheme("https"), curl.WithPath("/post"), curl.WithQueryParam("key", "a value"), curl.WithRawQuery("raw=%2F"),
+		),
+		Entry("connection",
+			curl.WithConnectionTimeout(5), curl.WithConnectTimeout(1500*time.Millisecond), curl.WithRetries(3, 1, 10),
+			curl.WithRetryConnectionRefused(true), curl.WithResolve("example.com", 80, "127.0.0.1"), curl.WithCaFile("ca.crt"),
+			curl.WithUnixSocket("/tmp/admin.sock"),
+		),
+		Entry("cookies",
+			curl.WithNamedCookie("session", "abc"), curl.WithNamedCookie("theme", "dark"), curl.WithCookieJar("jar.txt"),
+		),
+	)
+
+	It("executes the parsed command natively", func() {
+		var (
+			lastRequest *http.Request
+			lastBody    string
+		)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			lastRequest = r
+			b, _ := io.ReadAll(r.Body)
+			lastBody = string(b)
+		}))
+		defer server.Close()
+
+		opts, err := curl.ParseCommand(`curl -X PUT -H 'x-test: value' -u user:pass -d '{"a":1}' ` + server.URL + "/put?k=v")
+		Expect(err).NotTo(HaveOccurred())
+
+		resp, err := curl.ExecuteRequest(opts...)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		Expect(lastRequest.Method).To(Equal(http.MethodPut))
+		Expect(lastRequest.URL.String()).To(Equal("/put?k=v"))
+		Expect(lastRequest.Header.Get("x-test")).To(Equal("value"))
+		username, password, ok := lastRequest.BasicAuth()
+		Expect(ok).To(BeTrue())
+		Expect(username).To(Equal("user"))
+		Expect(password).To(Equal("pass"))
+		Expect(lastBody).To(Equal(`{"a":1}`))
+	})
+})
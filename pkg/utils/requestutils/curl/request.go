@@ -1,8 +1,18 @@
 package curl
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
+	"maps"
+	"net"
+	"net/http"
 	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // BuildArgs accepts a set of curl.Option and generates the list of arguments
@@ -37,8 +47,8 @@ func BuildArgs(options ...Option) []string {
 		retryMaxTime:      0,
 		ipv4Only:          false,
 		ipv6Only:          false,
-		cookie:            "",
 		cookieJar:         "",
+		maxRedirects:      -1,
 
 		additionalArgs: []string{},
 	}
@@ -56,18 +66,25 @@ type requestConfig struct {
 	ignoreServerCert  bool
 	silent            bool
 	connectionTimeout int // seconds
+	connectTimeout    time.Duration
 	headersOnly       bool
 	method            string
+	methodSet         bool
 	host              string
 	port              int
 	headers           map[string][]string
 	body              string
 	sni               string
-	caFile            string
-	path              string
-	queryParameters   map[string]string
+	// resolve maps host:port pairs to the address that should be connected to instead
+	resolve         map[string]string
+	unixSocket      string
+	caFile          string
+	path            string
+	queryParameters url.Values
+	rawQuery        string
 
 	cookie    string
+	cookies   []*http.Cookie
 	cookieJar string
 
 	scheme string
@@ -78,11 +95,18 @@ type requestConfig struct {
 	retryDelay             int
 	retryMaxTime           int
 	retryConnectionRefused bool
+	// retryTransientResponses also retries transient response status codes, only used by ExecuteRequest
+	retryTransientResponses bool
 
 	ipv4Only bool
 	ipv6Only bool
 
 	ignoreBody bool
+
+	followRedirects bool
+	// maxRedirects is the number of redirects to follow, or -1 to use the default
+	maxRedirects int
+
 	// HTTP protocol options
 	http11 bool
 	http2  bool
@@ -97,7 +121,81 @@ type requestConfig struct {
 	clientCert string
 	clientKey  string
 
+	// Native TLS options, only used by ExecuteRequest
+	rootCAs            *x509.CertPool
+	clientCertificates []tls.Certificate
+
+	// disableAutoDecompress returns compressed responses as sent, only used by ExecuteRequest
+	disableAutoDecompress bool
+	// bodyEncoding is the Content-Encoding the body is compressed with, only used by ExecuteRequest
+	bodyEncoding string
+
+	// Native connection pool options, only used by ExecuteRequest
+	maxIdleConns        int
+	maxIdleConnsPerHost int
+	idleConnTimeout     time.Duration
+
 	additionalArgs []string
+
+	// err accumulates any errors encountered while applying options
+	// It is returned by ExecuteRequest, and ignored when building args
+	err error
+}
+
+// addError records an error encountered while applying an Option
+func (c *requestConfig) addError(err error) {
+	c.err = errors.Join(c.err, err)
+}
+
+// hasHeader returns true if a value has been configured for the provided header, ignoring case
+func (c *requestConfig) hasHeader(key string) bool {
+	for h := range c.headers {
+		if strings.EqualFold(h, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// appendQuery returns the address with the configured raw query and query parameters encoded onto it
+// If the address already contains a query string, for example one provided via WithPath, they are appended to it
+func (c *requestConfig) appendQuery(address string) string {
+	var query []string
+	if c.rawQuery != "" {
+		query = append(query, c.rawQuery)
+	}
+	if len(c.queryParameters) > 0 {
+		query = append(query, c.queryParameters.Encode())
+	}
+	if len(query) == 0 {
+		return address
+	}
+	separator := "?"
+	if strings.Contains(address, "?") {
+		separator = "&"
+	}
+	return address + separator + strings.Join(query, "&")
+}
+
+// cookieHeader returns the value of the Cookie header for the configured cookies
+func (c *requestConfig) cookieHeader() string {
+	pairs := make([]string, 0, len(c.cookies)+1)
+	if c.cookie != "" {
+		pairs = append(pairs, c.cookie)
+	}
+	for _, cookie := range c.cookies {
+		pairs = append(pairs, cookie.String())
+	}
+	return strings.Join(pairs, "; ")
+}
+
+// deleteHeader removes any value configured for the provided header, ignoring case
+func (c *requestConfig) deleteHeader(key string) {
+	for h := range c.headers {
+		if strings.EqualFold(h, key) {
+			delete(c.headers, h)
+		}
+	}
 }
 
 func (c *requestConfig) generateArgs() []string {
@@ -112,9 +210,13 @@ func (c *requestConfig) generateArgs() []string {
 	if c.silent {
 		args = append(args, "-s")
 	}
+	if c.connectTimeout > 0 {
+		args = append(args, "--connect-timeout", strconv.FormatFloat(c.connectTimeout.Seconds(), 'f', -1, 64))
+	} else if c.connectionTimeout > 0 {
+		args = append(args, "--connect-timeout", fmt.Sprintf("%v", c.connectionTimeout))
+	}
 	if c.connectionTimeout > 0 {
-		seconds := fmt.Sprintf("%v", c.connectionTimeout)
-		args = append(args, "--connect-timeout", seconds, "--max-time", seconds)
+		args = append(args, "--max-time", fmt.Sprintf("%v", c.connectionTimeout))
 	}
 	if c.headersOnly {
 		args = append(args, "-I")
@@ -131,6 +233,13 @@ func (c *requestConfig) generateArgs() []string {
 	if c.caFile != "" {
 		args = append(args, "--cacert", c.caFile)
 	}
+	for _, hostPort := range slices.Sorted(maps.Keys(c.resolve)) {
+		host, port, _ := net.SplitHostPort(hostPort)
+		args = append(args, "--resolve", fmt.Sprintf("%s:%s:%s", host, port, c.resolve[hostPort]))
+	}
+	if c.unixSocket != "" {
+		args = append(args, "--unix-socket", c.unixSocket)
+	}
 	if c.body != "" {
 		args = append(args, "--data-binary", c.body)
 	}
@@ -147,6 +256,13 @@ func (c *requestConfig) generateArgs() []string {
 		args = append(args, "--retry-connrefused")
 	}
 
+	if c.followRedirects {
+		args = append(args, "-L")
+	}
+	if c.maxRedirects >= 0 {
+		args = append(args, "--max-redirs", strconv.Itoa(c.maxRedirects))
+	}
+
 	if c.proxyProto {
 		args = append(args, "--haproxy-protocol")
 	}
@@ -192,18 +308,11 @@ func (c *requestConfig) generateArgs() []string {
 		fullAddress = fmt.Sprintf("%s://%s:%d", c.scheme, c.sni, c.port)
 		args = append(args, "--connect-to", sniResolution)
 	} else {
-		fullAddress = fmt.Sprintf("%v://%s:%v/%s", c.scheme, c.host, c.port, c.path)
-		if len(c.queryParameters) > 0 {
-			values := url.Values{}
-			for k, v := range c.queryParameters {
-				values.Add(k, v)
-			}
-			fullAddress = fmt.Sprintf("%s?%s", fullAddress, values.Encode())
-		}
+		fullAddress = c.appendQuery(fmt.Sprintf("%v://%s:%v/%s", c.scheme, c.host, c.port, c.path))
 	}
 
-	if c.cookie != "" {
-		args = append(args, "--cookie", c.cookie)
+	if cookie := c.cookieHeader(); cookie != "" {
+		args = append(args, "--cookie", cookie)
 	}
 
 	if c.cookieJar != "" {
@@ -1,6 +1,9 @@
 package curl_test
 
 import (
+	"slices"
+	"time"
+
 	"github.com/onsi/gomega/types"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -29,6 +32,30 @@ var _ = Describe("Curl", func() {
 				curl.Silent(),
 				ContainElement("-s"),
 			),
+			Entry("WithConnectionTimeout",
+				curl.WithConnectionTimeout(5),
+				ContainElements("--connect-timeout", "5", "--max-time", "5"),
+			),
+			Entry("WithConnectTimeout",
+				curl.WithConnectTimeout(1500*time.Millisecond),
+				And(ContainElements("--connect-timeout", "1.5"), Not(ContainElement("--max-time"))),
+			),
+			Entry("WithFollowRedirects",
+				curl.WithFollowRedirects(true),
+				And(ContainElement("-L"), Not(ContainElement("--max-redirs"))),
+			),
+			Entry("WithMaxRedirects",
+				curl.WithMaxRedirects(3),
+				ContainElements("--max-redirs", "3"),
+			),
+			Entry("WithCookie",
+				curl.WithNamedCookie("session", "abc"),
+				ContainElements("--cookie", "session=abc"),
+			),
+			Entry("WithUnixSocket",
+				curl.WithUnixSocket("/tmp/admin.sock"),
+				ContainElements("--unix-socket", "/tmp/admin.sock"),
+			),
 			Entry("WithHeadersOnly",
 				curl.WithHeadersOnly(),
 				ContainElement("-I"),
@@ -49,8 +76,43 @@ var _ = Describe("Curl", func() {
 				curl.WithArgs([]string{"--custom-args"}),
 				ContainElement("--custom-args"),
 			),
+			Entry("WithResolve",
+				curl.WithResolve("example.com", 443, "127.0.0.1"),
+				ContainElements("--resolve", "example.com:443:127.0.0.1"),
+			),
+			Entry("WithQueryParam",
+				curl.WithQueryParam("key", "a value"),
+				ContainElement("http://127.0.0.1:8080/?key=a+value"),
+			),
+			Entry("WithRawQuery",
+				curl.WithRawQuery("key=a%20value"),
+				ContainElement("http://127.0.0.1:8080/?key=a%20value"),
+			),
 		)
 
+		It("uses the connect timeout for the connection phase and the connection timeout for the whole request", func() {
+			args := curl.BuildArgs(curl.WithConnectionTimeout(5), curl.WithConnectTimeout(time.Second))
+			i := slices.Index(args, "--connect-timeout")
+			Expect(i).To(BeNumerically(">=", 0))
+			Expect(args[i+1]).To(Equal("1"))
+			Expect(slices.Index(args[i+1:], "--connect-timeout")).To(Equal(-1), "--connect-timeout should only be set once")
+			j := slices.Index(args, "--max-time")
+			Expect(j).To(BeNumerically(">=", 0))
+			Expect(args[j+1]).To(Equal("5"))
+		})
+
+		It("sends all configured cookies in a single --cookie arg", func() {
+			args := curl.BuildArgs(curl.WithNamedCookie("session", "abc"), curl.WithNamedCookie("theme", "dark"))
+			Expect(args).To(ContainElements("--cookie", "session=abc; theme=dark"))
+			i := slices.Index(args, "--cookie")
+			Expect(slices.Index(args[i+1:], "--cookie")).To(Equal(-1), "--cookie should only be set once")
+		})
+
+		It("combines a raw cookie with named cookies", func() {
+			args := curl.BuildArgs(curl.WithCookie("api-key=k-123"), curl.WithNamedCookie("session", "abc"))
+			Expect(args).To(ContainElements("--cookie", "api-key=k-123; session=abc"))
+		})
+
 	})
 
 })
@@ -0,0 +1,33 @@
+// Package sliceutils contains generic helpers for working with slices.
+package sliceutils
+
+import (
+	"slices"
+)
+
+// AppendIfMissingG returns a slice, with the provided value included
+// If the value already exists in the slice, it will not be duplicated
+func AppendIfMissingG[T comparable](slice []T, value T) []T {
+	if slices.Contains(slice, value) {
+		return slice
+	}
+	return append(slice, value)
+}
+
+// DeleteOneByValueG removes the first instance of value from the slice, if present.
+// Like slices.Delete, it modifies the contents of the provided slice.
+// Otherwise returns the original slice.
+func DeleteOneByValueG[T comparable](slice []T, value T) []T {
+	index := slices.Index(slice, value)
+	if index == -1 {
+		return slice
+	}
+	return slices.Delete(slice, index, index+1)
+}
+
+// DeleteByPredicate removes every element of the slice for which predicate returns true,
+// preserving the order of the remaining elements.
+// Like slices.DeleteFunc, it modifies the contents of the provided slice.
+func DeleteByPredicate[T any](slice []T, predicate func(T) bool) []T {
+	return slices.DeleteFunc(slice, predicate)
+}
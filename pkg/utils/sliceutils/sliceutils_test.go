@@ -0,0 +1,93 @@
+package sliceutils
+
+import (
+	"slices"
+	"testing"
+)
+
+type point struct {
+	x, y int
+}
+
+func TestAppendIfMissingG(t *testing.T) {
+	t.Run("int", func(t *testing.T) {
+		testAppendIfMissing(t, []int{1, 2}, 3, []int{1, 2, 3})
+		testAppendIfMissing(t, []int{1, 2}, 2, []int{1, 2})
+		testAppendIfMissing(t, nil, 1, []int{1})
+	})
+	t.Run("string", func(t *testing.T) {
+		testAppendIfMissing(t, []string{"a", "b"}, "c", []string{"a", "b", "c"})
+		testAppendIfMissing(t, []string{"a", "b"}, "a", []string{"a", "b"})
+		testAppendIfMissing(t, nil, "a", []string{"a"})
+	})
+	t.Run("struct", func(t *testing.T) {
+		testAppendIfMissing(t, []point{{1, 2}}, point{2, 1}, []point{{1, 2}, {2, 1}})
+		testAppendIfMissing(t, []point{{1, 2}}, point{1, 2}, []point{{1, 2}})
+		testAppendIfMissing(t, nil, point{}, []point{{}})
+	})
+}
+
+func TestDeleteOneByValueG(t *testing.T) {
+	t.Run("int", func(t *testing.T) {
+		testDeleteOneByValue(t, []int{1, 2, 3}, 2, []int{1, 3})
+		// only the first instance is deleted
+		testDeleteOneByValue(t, []int{1, 2, 1}, 1, []int{2, 1})
+		testDeleteOneByValue(t, []int{1, 2}, 3, []int{1, 2})
+		testDeleteOneByValue(t, nil, 1, nil)
+	})
+	t.Run("string", func(t *testing.T) {
+		testDeleteOneByValue(t, []string{"a", "b", "c"}, "c", []string{"a", "b"})
+		testDeleteOneByValue(t, []string{"a", "b", "a"}, "a", []string{"b", "a"})
+		testDeleteOneByValue(t, []string{"a"}, "b", []string{"a"})
+		testDeleteOneByValue(t, []string{"a"}, "a", []string{})
+	})
+	t.Run("struct", func(t *testing.T) {
+		testDeleteOneByValue(t, []point{{1, 2}, {2, 1}}, point{1, 2}, []point{{2, 1}})
+		testDeleteOneByValue(t, []point{{1, 2}}, point{2, 1}, []point{{1, 2}})
+	})
+}
+
+func TestDeleteByPredicate(t *testing.T) {
+	isEven := func(i int) bool { return i%2 == 0 }
+	t.Run("int", func(t *testing.T) {
+		testDeleteByPredicate(t, []int{1, 3, 5}, isEven, []int{1, 3, 5})
+		testDeleteByPredicate(t, []int{1, 2, 3}, isEven, []int{1, 3})
+		testDeleteByPredicate(t, []int{2, 1, 4, 3, 6}, isEven, []int{1, 3})
+		testDeleteByPredicate(t, []int{2, 4}, isEven, []int{})
+		testDeleteByPredicate(t, []int{}, isEven, []int{})
+		testDeleteByPredicate(t, nil, isEven, nil)
+	})
+	t.Run("slice", func(t *testing.T) {
+		// slices are not comparable, so only a predicate can match them
+		isEmpty := func(s []string) bool { return len(s) == 0 }
+		in := [][]string{{"a"}, nil, {"b", "c"}, {}}
+		if actual := DeleteByPredicate(in, isEmpty); len(actual) != 2 || actual[0][0] != "a" || actual[1][0] != "b" {
+			t.Errorf("DeleteByPredicate() = %v, expected [[a] [b c]]", actual)
+		}
+	})
+}
+
+func testAppendIfMissing[T comparable](t *testing.T, slice []T, value T, expected []T) {
+	t.Helper()
+	if actual := AppendIfMissingG(slice, value); !slices.Equal(actual, expected) {
+		t.Errorf("AppendIfMissingG(%v, %v) = %v, expected %v", slice, value, actual, expected)
+	}
+}
+
+func testDeleteOneByValue[T comparable](t *testing.T, slice []T, value T, expected []T) {
+	t.Helper()
+	// DeleteOneByValueG modifies the input, so format it before the call
+	input := slices.Clone(slice)
+	if actual := DeleteOneByValueG(slice, value); !slices.Equal(actual, expected) {
+		t.Errorf("DeleteOneByValueG(%v, %v) = %v, expected %v", input, value, actual, expected)
+	}
+}
+
+func testDeleteByPredicate[T comparable](t *testing.T, slice []T, predicate func(T) bool, expected []T) {
+	t.Helper()
+	// DeleteByPredicate modifies the input, so format it before the call
+	input := slices.Clone(slice)
+	if actual := DeleteByPredicate(slice, predicate); !slices.Equal(actual, expected) {
+		t.Errorf("DeleteByPredicate(%v) = %v, expected %v", input, actual, expected)
+	}
+}
@@ -1,29 +1,44 @@
+// Package stringutils contains helpers for working with strings.
+//
+// The slice helpers AppendIfMissing and DeleteOneByValue are deprecated in favour of their generic equivalents
+// in pkg/utils/sliceutils, which work with slices of any comparable type. They are drop-in replacements:
+// replace stringutils.AppendIfMissing with sliceutils.AppendIfMissingG, and stringutils.DeleteOneByValue
+// with sliceutils.DeleteOneByValueG.
 package stringutils
 
 import (
-	slices0 "slices"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"unicode/utf8"
 
-	slices "golang.org/x/exp/slices"
+	"github.com/kgateway-dev/kgateway/v2/pkg/utils/sliceutils"
 )
 
 // Only deletes the first instance of value!
-// Takes a slice and a value and if that value is found, uses Delete from the exp.slices package to remove it.
+// Takes a slice and a value and if that value is found, removes it.
 // Otherwise returns the original slice.
+//
+// Deprecated: Use sliceutils.DeleteOneByValueG instead.
 func DeleteOneByValue(slice []string, value string) []string {
-	index := slices.Index(slice, value)
-	if index == -1 {
-		return slice
-	}
-	return slices.Delete(slice, index, index+1)
+	return sliceutils.DeleteOneByValueG(slice, value)
+}
+
+// DeleteAllByValue removes every instance of value from the slice, preserving the order of the remaining elements.
+// Like slices.DeleteFunc, it modifies the contents of the provided slice.
+func DeleteAllByValue(slice []string, value string) []string {
+	return slices.DeleteFunc(slice, func(s string) bool {
+		return s == value
+	})
 }
 
 // AppendIfMissing returns a slice, with the provided value included
 // If the value already exists in the slice, it will not be duplicated
+//
+// Deprecated: Use sliceutils.AppendIfMissingG instead.
 func AppendIfMissing(slice []string, value string) []string {
-	if slices0.Contains(slice, value) {
-		return slice
-	}
-	return append(slice, value)
+	return sliceutils.AppendIfMissingG(slice, value)
 }
 
 // TruncateMaxLength returns a string truncated to the specified maximum length.
@@ -35,3 +50,67 @@ func TruncateMaxLength(s string, maxLen int) string {
 	}
 	return s[:maxLen]
 }
+
+// TruncateMaxLengthRunes returns a string truncated to the specified maximum number of runes.
+// Unlike TruncateMaxLength, it never splits a multi-byte UTF-8 sequence, so the result of truncating
+// a valid UTF-8 string is always valid UTF-8.
+func TruncateMaxLengthRunes(s string, maxLen int) string {
+	runes := 0
+	for i := range s {
+		if runes == maxLen {
+			return s[:i]
+		}
+		runes++
+	}
+	return s
+}
+
+// TruncateMaxLengthBytes returns a string truncated to at most maxLen bytes.
+// Unlike TruncateMaxLength, it never splits a multi-byte UTF-8 sequence: if the cut would fall inside one,
+// the whole sequence is dropped, so the result may be shorter than maxLen.
+func TruncateMaxLengthBytes(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
+	}
+	cut := maxLen
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut]
+}
+
+// MinHashHexLen is the minimum number of hex characters of the hash used by SafeTruncateAndHashN
+const MinHashHexLen = 8
+
+// SafeTruncateAndHashN returns s if it is no longer than maxLen.
+// Otherwise, it returns s truncated such that, when joined by a `-` with the first hashHexLen
+// hex characters of the SHA-256 hash of s, the result is at most maxLen bytes long. The truncation never
+// splits a multi-byte UTF-8 sequence, so the result is exactly maxLen bytes long for ASCII input.
+// Since the hash is computed over the full string, distinct long inputs sharing a prefix produce distinct results.
+//
+// It panics if hashHexLen is less than MinHashHexLen or greater than the length of a SHA-256 hex digest,
+// or if maxLen is too short to fit the hash and separator.
+func SafeTruncateAndHashN(s string, maxLen, hashHexLen int) string {
+	return SafeTruncateAndHashWithOpts(s, maxLen, hashHexLen, "-")
+}
+
+// SafeTruncateAndHashWithOpts is like SafeTruncateAndHashN, but joins the truncated string and the hash with sep
+// instead of `-`. This is useful when `-` is not allowed in the resulting name. sep may be empty.
+//
+// It panics if hashHexLen is less than MinHashHexLen or greater than the length of a SHA-256 hex digest,
+// or if maxLen is too short to fit the hash and separator.
+func SafeTruncateAndHashWithOpts(s string, maxLen, hashHexLen int, sep string) string {
+	if hashHexLen < MinHashHexLen || hashHexLen > sha256.Size*2 {
+		panic(fmt.Sprintf("stringutils: hashHexLen must be between %d and %d, got %d", MinHashHexLen, sha256.Size*2, hashHexLen))
+	}
+	if maxLen < hashHexLen+len(sep) {
+		panic(fmt.Sprintf("stringutils: maxLen must be at least the hash and separator length (%d), got %d", hashHexLen+len(sep), maxLen))
+	}
+	if len(s) <= maxLen {
+		return s
+	}
+
+	sum := sha256.Sum256([]byte(s))
+	hash := hex.EncodeToString(sum[:])[:hashHexLen]
+	return TruncateMaxLengthBytes(s, maxLen-hashHexLen-len(sep)) + sep + hash
+}
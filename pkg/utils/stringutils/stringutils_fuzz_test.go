@@ -0,0 +1,55 @@
+package stringutils_test
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/utils/stringutils"
+)
+
+// maxNameLen is the maximum length of a Kubernetes resource name
+const maxNameLen = 253
+
+func FuzzSafeTruncateAndHashN(f *testing.F) {
+	f.Add("short", "other")
+	f.Add(strings.Repeat("a", 100)+"1", strings.Repeat("a", 100)+"2")
+	f.Add(strings.Repeat("b", maxNameLen), strings.Repeat("b", maxNameLen-1))
+
+	f.Fuzz(func(t *testing.T, a, b string) {
+		if a == b || len(a) > maxNameLen || len(b) > maxNameLen {
+			t.Skip()
+		}
+		gotA := stringutils.SafeTruncateAndHashN(a, 63, 16)
+		gotB := stringutils.SafeTruncateAndHashN(b, 63, 16)
+		if len(gotA) > 63 || len(gotB) > 63 {
+			t.Fatalf("expected results no longer than 63 characters, got %q and %q", gotA, gotB)
+		}
+		if utf8.ValidString(a) && !utf8.ValidString(gotA) {
+			t.Fatalf("valid UTF-8 input %q produced invalid UTF-8 %q", a, gotA)
+		}
+		if gotA == gotB {
+			t.Fatalf("distinct inputs %q and %q produced the same result %q", a, b, gotA)
+		}
+	})
+}
+
+func BenchmarkTruncate(b *testing.B) {
+	s := strings.Repeat("a", maxNameLen)
+
+	b.Run("TruncateMaxLength", func(b *testing.B) {
+		for b.Loop() {
+			stringutils.TruncateMaxLength(s, 63)
+		}
+	})
+	b.Run("SafeTruncateAndHashN/8", func(b *testing.B) {
+		for b.Loop() {
+			stringutils.SafeTruncateAndHashN(s, 63, 8)
+		}
+	})
+	b.Run("SafeTruncateAndHashN/16", func(b *testing.B) {
+		for b.Loop() {
+			stringutils.SafeTruncateAndHashN(s, 63, 16)
+		}
+	})
+}
@@ -1,6 +1,11 @@
 package stringutils_test
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"unicode/utf8"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -21,6 +26,17 @@ var _ = Describe("StringUtils", func() {
 		Entry("Not Found", []string{"one", "two", "three"}, "four", []string{"one", "two", "three"}),
 	)
 
+	DescribeTable("DeleteAllByValue", func(array []string, value string, expected []string) {
+		Expect(DeleteAllByValue(array, value)).To(Equal(expected))
+	},
+		Entry("Empty", []string{}, "one", []string{}),
+		Entry("Nil", nil, "one", nil),
+		Entry("No matches", []string{"one", "two", "three"}, "four", []string{"one", "two", "three"}),
+		Entry("One match", []string{"one", "two", "three"}, "two", []string{"one", "three"}),
+		Entry("Many matches", []string{"one", "two", "one", "three", "one"}, "one", []string{"two", "three"}),
+		Entry("All match", []string{"one", "one"}, "one", []string{}),
+	)
+
 	DescribeTable("TruncateMaxLength", func(val string, maxLen int, want string) {
 		Expect(TruncateMaxLength(val, maxLen)).To(Equal(want))
 	},
@@ -28,4 +44,97 @@ var _ = Describe("StringUtils", func() {
 		Entry("Same", "abc", 3, "abc"),
 		Entry("Longer", "abcdefgh", 3, "abc"),
 	)
+
+	DescribeTable("TruncateMaxLengthRunes", func(val string, maxLen int, want string) {
+		got := TruncateMaxLengthRunes(val, maxLen)
+		Expect(got).To(Equal(want))
+		Expect(utf8.ValidString(got)).To(BeTrue())
+	},
+		Entry("Smaller", "abc", 10, "abc"),
+		Entry("Same", "abc", 3, "abc"),
+		Entry("Longer", "abcdefgh", 3, "abc"),
+		Entry("Zero", "abc", 0, ""),
+		Entry("Empty", "", 3, ""),
+		// each of these runes is 2 bytes, so byte truncation at 3 would split the second rune
+		Entry("Multi-byte", "шлюз", 2, "шл"),
+		Entry("Multi-byte same rune count", "шлюз", 4, "шлюз"),
+		Entry("Mixed widths", "a网关🚪b", 4, "a网关🚪"),
+	)
+
+	DescribeTable("TruncateMaxLengthBytes", func(val string, maxLen int, want string) {
+		got := TruncateMaxLengthBytes(val, maxLen)
+		Expect(got).To(Equal(want))
+		Expect(len(got)).To(BeNumerically("<=", maxLen))
+		Expect(utf8.ValidString(got)).To(BeTrue())
+	},
+		Entry("Smaller", "abc", 10, "abc"),
+		Entry("Same", "abc", 3, "abc"),
+		Entry("Longer", "abcdefgh", 3, "abc"),
+		Entry("Zero", "abc", 0, ""),
+		// "привет" is 6 runes of 2 bytes each
+		Entry("Cyrillic on boundary", "привет", 4, "пр"),
+		Entry("Cyrillic mid-rune", "привет", 5, "пр"),
+		// "网关" is 2 runes of 3 bytes each
+		Entry("Chinese on boundary", "网关", 3, "网"),
+		Entry("Chinese mid-rune", "网关", 5, "网"),
+		Entry("Chinese shorter than first rune", "网关", 2, ""),
+		// "🚪🚪" is 2 runes of 4 bytes each
+		Entry("Emoji on boundary", "🚪🚪", 4, "🚪"),
+		Entry("Emoji mid-rune", "🚪🚪", 7, "🚪"),
+		Entry("Mixed widths", "a网关🚪b", 6, "a网"),
+	)
+
+	DescribeTable("SafeTruncateAndHashN", func(val string, maxLen, hashHexLen int, want string) {
+		Expect(SafeTruncateAndHashN(val, maxLen, hashHexLen)).To(Equal(want))
+	},
+		Entry("Smaller", "abc", 10, 8, "abc"),
+		Entry("Same", "abcdefghij", 10, 8, "abcdefghij"),
+		// sha256("abcdefghijk") starts with ca2f2069
+		Entry("Longer", "abcdefghijk", 10, 8, "a-ca2f2069"),
+		Entry("Longer with longer hash", "abcdefghijk", 20, 16, "abcdefghijk"),
+		Entry("Longer than maxLen with longer hash", "abcdefghijklmnopqrstu", 20, 16, "abc-"+hashPrefix("abcdefghijklmnopqrstu", 16)),
+		// 4 bytes are left for "приветмир", which is cut on a rune boundary
+		Entry("Multi-byte on boundary", "приветмир", 13, 8, "пр-"+hashPrefix("приветмир", 8)),
+		// 3 bytes are left, which would split the second rune, so only the first is kept
+		Entry("Multi-byte mid-rune", "приветмир", 12, 8, "п-"+hashPrefix("приветмир", 8)),
+	)
+
+	It("SafeTruncateAndHashN produces distinct results for long strings sharing a prefix", func() {
+		prefix := strings.Repeat("a", 100)
+		Expect(SafeTruncateAndHashN(prefix+"1", 63, 16)).NotTo(Equal(SafeTruncateAndHashN(prefix+"2", 63, 16)))
+	})
+
+	DescribeTable("SafeTruncateAndHashWithOpts", func(val string, maxLen, hashHexLen int, sep, want string) {
+		Expect(SafeTruncateAndHashWithOpts(val, maxLen, hashHexLen, sep)).To(Equal(want))
+	},
+		Entry("Smaller", "abc", 10, 8, ".", "abc"),
+		// sha256("abcdefghijk") starts with ca2f2069
+		Entry("Single character separator", "abcdefghijk", 10, 8, ".", "a.ca2f2069"),
+		Entry("Multi-character separator", "abcdefghijklmnopqrstu", 20, 8, "--", "abcdefghij--"+hashPrefix("abcdefghijklmnopqrstu", 8)),
+		Entry("Empty separator", "abcdefghijk", 10, 8, "", "abca2f2069"),
+		Entry("Only room for the hash", "abcdefghijk", 8, 8, "", "ca2f2069"),
+		Entry("Default separator matches SafeTruncateAndHashN", "abcdefghijk", 10, 8, "-", SafeTruncateAndHashN("abcdefghijk", 10, 8)),
+	)
+
+	DescribeTable("SafeTruncateAndHashWithOpts panics on invalid lengths", func(maxLen, hashHexLen int, sep string) {
+		Expect(func() { SafeTruncateAndHashWithOpts("abc", maxLen, hashHexLen, sep) }).To(Panic())
+	},
+		Entry("hash too short", 63, 7, "-"),
+		Entry("hash too long", 100, 65, "-"),
+		Entry("maxLen shorter than hash", 7, 8, ""),
+		Entry("maxLen shorter than hash and separator", 9, 8, "--"),
+	)
+
+	DescribeTable("SafeTruncateAndHashN panics on invalid lengths", func(maxLen, hashHexLen int) {
+		Expect(func() { SafeTruncateAndHashN("abc", maxLen, hashHexLen) }).To(Panic())
+	},
+		Entry("hash too short", 63, 7),
+		Entry("hash too long", 100, 65),
+		Entry("maxLen too short", 8, 8),
+	)
 })
+
+func hashPrefix(s string, n int) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])[:n]
+}
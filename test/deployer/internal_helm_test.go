@@ -100,6 +100,28 @@ wIDAQABMA0GCSqGSIb3DQEBCwUAA4IBAQBtestcertdata
 			Name:      "gateway with priorityClassName",
 			InputFile: "priority-class-name",
 		},
+		{
+			Name:      "gwparams with networkPolicyEnabled",
+			InputFile: "network-policy",
+			Validate: func(t *testing.T, outputYaml string) {
+				t.Helper()
+				assert.Contains(t, outputYaml, "kind: NetworkPolicy",
+					"a NetworkPolicy should be rendered when networkPolicyEnabled is true")
+				assert.Contains(t, outputYaml, "- port: 9091",
+					"the NetworkPolicy should allow the stats port when stats are enabled")
+				assert.Contains(t, outputYaml, "app: client",
+					"the NetworkPolicy should only allow the listener ports from the allowed peers")
+			},
+		},
+		{
+			Name:      "gwparams with networkPolicyEnabled false",
+			InputFile: "network-policy-disabled",
+			Validate: func(t *testing.T, outputYaml string) {
+				t.Helper()
+				assert.NotContains(t, outputYaml, "kind: NetworkPolicy",
+					"no NetworkPolicy should be rendered when networkPolicyEnabled is false")
+			},
+		},
 		{
 			Name:      "gwparams with omitDefaultSecurityContext via GWC",
 			InputFile: "omit-default-security-context",
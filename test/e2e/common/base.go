@@ -52,6 +52,9 @@ func (g *Gateway) Send(t *testing.T, match *matchers.HttpResponse, opts ...curl.
 			return err
 		}
 		defer r.Body.Close()
+		if attempts := curl.Attempts(r); attempts > 1 {
+			t.Logf("request succeeded after %d attempts", attempts)
+		}
 		mm := matchers.HaveHttpResponse(match)
 		success, err := mm.Match(r)
 		if err != nil {
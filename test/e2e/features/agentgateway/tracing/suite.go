@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/suite"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/shared"
 	"github.com/kgateway-dev/kgateway/v2/pkg/utils/fsutils"
 	"github.com/kgateway-dev/kgateway/v2/pkg/utils/kubeutils"
 	"github.com/kgateway-dev/kgateway/v2/pkg/utils/requestutils/curl"
@@ -28,6 +29,7 @@ var _ e2e.NewSuiteFunc = NewTestingSuite
 var (
 	setupManifest        = filepath.Join(fsutils.MustGetThisDir(), "testdata", "setup.yaml")
 	tracingSetupManifest = filepath.Join(fsutils.MustGetThisDir(), "testdata", "tracing.yaml")
+	badTracingManifest   = filepath.Join(fsutils.MustGetThisDir(), "testdata", "badtracing.yaml")
 
 	proxyServiceObjectMeta = metav1.ObjectMeta{
 		Name:      "gw",
@@ -49,6 +51,11 @@ var (
 				tracingSetupManifest,
 			},
 		},
+		"TestOTelTracingInvalidBackend": {
+			Manifests: []string{
+				badTracingManifest,
+			},
+		},
 	}
 )
 
@@ -67,6 +74,15 @@ func (s *testingSuite) TestOTelTracing() {
 	s.testOTelTracing()
 }
 
+// TestOTelTracingInvalidBackend verifies that a tracing policy whose collector Service does not exist
+// is reported as invalid and not attached.
+func (s *testingSuite) TestOTelTracingInvalidBackend() {
+	s.TestInstallation.Assertions.EventuallyAgwPolicyConditionWithReason(
+		s.Ctx, "agw-bad-tracing", "default", "Accepted", metav1.ConditionTrue, string(shared.PolicyReasonInvalid), 30*time.Second)
+	s.TestInstallation.Assertions.EventuallyAgwPolicyConditionWithReason(
+		s.Ctx, "agw-bad-tracing", "default", "Attached", metav1.ConditionFalse, string(shared.PolicyReasonPending), 30*time.Second)
+}
+
 // testOTelTracing makes a request to the httpbin service
 // and checks if the collector pod logs contain the expected lines.
 func (s *testingSuite) testOTelTracing() {
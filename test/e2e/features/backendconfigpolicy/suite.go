@@ -7,6 +7,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
+	"sync"
 	"time"
 
 	adminv3 "github.com/envoyproxy/go-control-plane/envoy/admin/v3"
@@ -85,6 +87,11 @@ func (s *testingSuite) SetupSuite() {
 			testdefaults.CurlPodManifest,
 			setupManifest,
 		},
+		"TestBackendConfigPolicyLeastRequest": {
+			testdefaults.CurlPodManifest,
+			setupManifest,
+			leastRequestManifest,
+		},
 	}
 }
 
@@ -346,6 +353,99 @@ func (s *testingSuite) TestBackendConfigPolicyOutlierDetection() {
 	})
 }
 
+func (s *testingSuite) TestBackendConfigPolicyLeastRequest() {
+	s.testInstallation.Assertions.EventuallyObjectsExist(s.ctx, echoDeployment)
+	s.testInstallation.Assertions.EventuallyPodsRunning(s.ctx, echoDeployment.GetNamespace(), metav1.ListOptions{
+		LabelSelector: testdefaults.WellKnownAppLabel + "=echo",
+	}, time.Minute)
+
+	// envoy config should use the least request load balancer for the echo cluster
+	s.testInstallation.Assertions.AssertEnvoyAdminApi(s.ctx, proxyObjectMeta, func(ctx context.Context, adminClient *admincli.Client) {
+		s.testInstallation.Assertions.Gomega.Eventually(func(g gomega.Gomega) {
+			clusters, err := adminClient.GetDynamicClusters(ctx)
+			g.Expect(err).NotTo(gomega.HaveOccurred(), "can get dynamic clusters from config dump")
+
+			cluster, ok := clusters["kube_default_echo_8080"]
+			g.Expect(ok).To(gomega.BeTrue(), "cluster should be in list")
+			policies := cluster.GetLoadBalancingPolicy().GetPolicies()
+			g.Expect(policies).To(gomega.HaveLen(1))
+			g.Expect(policies[0].GetTypedExtensionConfig().GetName()).To(gomega.Equal("envoy.load_balancing_policies.least_request"))
+		}).
+			WithContext(ctx).
+			WithTimeout(time.Second * 10).
+			WithPolling(time.Millisecond * 200).
+			Should(gomega.Succeed())
+	})
+
+	curlOpts := []curl.Option{
+		curl.WithHost(kubeutils.ServiceFQDN(proxyObjectMeta)),
+		curl.WithHostHeader("echo.example.com"),
+		curl.WithPort(8080),
+		curl.WithConnectionTimeout(10),
+		curl.WithRetries(3, 0, 10),
+		curl.WithRetryConnectionRefused(true),
+	}
+	s.testInstallation.Assertions.AssertEventualCurlResponse(
+		s.ctx,
+		testdefaults.CurlPodExecOpt,
+		curlOpts,
+		&testmatchers.HttpResponse{
+			StatusCode: http.StatusOK,
+			Body:       gomega.ContainSubstring("pod=echo-"),
+		},
+	)
+
+	// send requests from several concurrent workers and count the pod which served each of them
+	const (
+		workers           = 10
+		requestsPerWorker = 9
+		replicas          = 3
+	)
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		podCount = map[string]int{}
+		failures []error
+	)
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range requestsPerWorker {
+				resp, err := s.testInstallation.ClusterContext.Cli.CurlFromPod(s.ctx, testdefaults.CurlPodExecOpt, curlOpts...)
+				mu.Lock()
+				if err != nil {
+					failures = append(failures, err)
+				} else {
+					podCount[echoPodName(resp.StdOut)]++
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	s.Require().Empty(failures, "all requests should succeed")
+	s.Require().NotContains(podCount, "", "every response should name the pod which served it")
+	s.Require().Len(podCount, replicas, "requests should be served by every replica: %v", podCount)
+	// with an even spread each replica serves a third of the requests; allow it to serve as few as half of that
+	// so the check is not flaky, while still catching a balancer which favours some replicas
+	minPerPod := workers * requestsPerWorker / replicas / 2
+	for pod, count := range podCount {
+		s.Assert().GreaterOrEqual(count, minPerPod, "pod %s served too few requests: %v", pod, podCount)
+	}
+}
+
+// echoPodName returns the pod name in a response from the echo server, which responds with "pod=<name>"
+func echoPodName(response string) string {
+	for line := range strings.SplitSeq(response, "\n") {
+		if _, name, ok := strings.Cut(line, "pod="); ok {
+			return strings.TrimSpace(name)
+		}
+	}
+	return ""
+}
+
 const (
 	kgatewayControllerName = "kgateway.dev/kgateway"
 	otherControllerName    = "other-controller.example.com/controller"
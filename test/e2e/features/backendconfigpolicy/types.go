@@ -21,6 +21,7 @@ var (
 	systemCAManifest         = filepath.Join(fsutils.MustGetThisDir(), "testdata", "system-ca.yaml")
 	outlierDetectionManifest = filepath.Join(fsutils.MustGetThisDir(), "testdata", "outlierdetection.yaml")
 	missingTargetManifest    = filepath.Join(fsutils.MustGetThisDir(), "testdata", "missing-target.yaml")
+	leastRequestManifest     = filepath.Join(fsutils.MustGetThisDir(), "testdata", "least-request.yaml")
 	// objects
 	proxyObjectMeta = metav1.ObjectMeta{
 		Name:      "gw",
@@ -39,4 +40,8 @@ var (
 		Namespace: "default",
 	}
 	httpbinDeployment = &appsv1.Deployment{ObjectMeta: httpbinMeta}
+	echoDeployment    = &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+		Name:      "echo",
+		Namespace: "default",
+	}}
 )
@@ -5,6 +5,7 @@ package deployer
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/onsi/gomega"
@@ -13,6 +14,8 @@ import (
 	"github.com/stretchr/testify/suite"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -23,10 +26,12 @@ import (
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
 	"github.com/kgateway-dev/kgateway/v2/pkg/utils/kubeutils"
+	"github.com/kgateway-dev/kgateway/v2/pkg/utils/requestutils/curl"
 	"github.com/kgateway-dev/kgateway/v2/test/e2e"
 	"github.com/kgateway-dev/kgateway/v2/test/e2e/defaults"
 	"github.com/kgateway-dev/kgateway/v2/test/e2e/tests/base"
 	"github.com/kgateway-dev/kgateway/v2/test/envoyutils/admincli"
+	testmatchers "github.com/kgateway-dev/kgateway/v2/test/gomega/matchers"
 	"github.com/kgateway-dev/kgateway/v2/test/testutils"
 )
 
@@ -54,9 +59,15 @@ var (
 		"TestProvisionResourcesNotUpdatedWithInvalidParameters": {
 			Manifests: []string{gatewayWithParameters},
 		},
+		"TestInvalidGatewayParametersReportedOnGateway": {
+			Manifests: []string{gatewayWithParameters},
+		},
 		"TestSelfManagedGateway": {
 			Manifests: []string{selfManagedGateway},
 		},
+		"TestNetworkPolicyRestrictsProxyIngress": {
+			Manifests: []string{defaults.CurlPodManifest, gatewayWithParameters},
+		},
 	}
 )
 
@@ -290,6 +301,36 @@ func (s *testingSuite) TestProvisionResourcesNotUpdatedWithInvalidParameters() {
 	}, "30s", "1s").Should(gomega.Succeed())
 }
 
+func (s *testingSuite) TestInvalidGatewayParametersReportedOnGateway() {
+	s.TestInstallation.Assertions.EventuallyReadyReplicas(s.Ctx, proxyObjectMeta, gomega.Equal(1))
+
+	s.patchGatewayParameters(gwParamsDefaultObjectMeta, func(parameters *kgateway.GatewayParameters) {
+		// a rollingUpdate config is not allowed with the Recreate strategy, so the deployer should
+		// reject these parameters and report the offending field on the Gateway
+		parameters.Spec.Kube.Deployment.Strategy = &appsv1.DeploymentStrategy{
+			Type: appsv1.RecreateDeploymentStrategyType,
+			RollingUpdate: &appsv1.RollingUpdateDeployment{
+				MaxSurge: ptr.To(intstr.FromInt32(1)),
+			},
+		}
+	})
+
+	s.TestInstallation.Assertions.Gomega.Eventually(func(g gomega.Gomega) {
+		gw := &gwv1.Gateway{}
+		err := s.TestInstallation.ClusterContext.Client.Get(s.Ctx, client.ObjectKey{
+			Namespace: proxyObjectMeta.Namespace,
+			Name:      proxyObjectMeta.Name,
+		}, gw)
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+
+		condition := meta.FindStatusCondition(gw.Status.Conditions, string(gwv1.GatewayConditionAccepted))
+		g.Expect(condition).NotTo(gomega.BeNil())
+		g.Expect(condition.Status).To(gomega.Equal(metav1.ConditionFalse))
+		g.Expect(condition.Reason).To(gomega.Equal(string(gwv1.GatewayReasonInvalidParameters)))
+		g.Expect(condition.Message).To(gomega.ContainSubstring("spec.kube.deployment.strategy.rollingUpdate"))
+	}, "30s", "1s").Should(gomega.Succeed())
+}
+
 func (s *testingSuite) TestSelfManagedGateway() {
 	s.Require().EventuallyWithT(func(c *assert.CollectT) {
 		gw := &gwv1.Gateway{}
@@ -319,6 +360,108 @@ func (s *testingSuite) TestSelfManagedGateway() {
 	)
 }
 
+// TestNetworkPolicyRestrictsProxyIngress tests that enabling the NetworkPolicy on the GatewayParameters
+// only lets the allowed peers reach the listener of the proxy, that no pod can reach the proxy on a port that is
+// not a listener port, and that disabling it removes the NetworkPolicy again.
+// NetworkPolicies are only enforced when the cluster runs a CNI which supports them, which the default kind CNI
+// does not, so this test only runs when NETWORK_POLICY_ENFORCED is set.
+func (s *testingSuite) TestNetworkPolicyRestrictsProxyIngress() {
+	if !testutils.ShouldEnforceNetworkPolicies() {
+		s.T().Skipf("Skipping: set %s when the cluster CNI enforces NetworkPolicies", testutils.NetworkPolicyEnforced)
+	}
+
+	s.TestInstallation.Assertions.EventuallyReadyReplicas(s.Ctx, proxyObjectMeta, gomega.Equal(1))
+
+	pods, err := kubeutils.GetReadyPodsForDeployment(s.Ctx, s.TestInstallation.ClusterContext.Clientset, proxyObjectMeta)
+	s.Require().NoError(err)
+	s.Require().Len(pods, 1)
+	pod := &corev1.Pod{}
+	err = s.TestInstallation.ClusterContext.Client.Get(s.Ctx, client.ObjectKey{
+		Namespace: proxyObjectMeta.Namespace,
+		Name:      pods[0],
+	}, pod)
+	s.Require().NoError(err)
+
+	// the readiness port of the proxy is not a listener port, so it is only reachable without the NetworkPolicy
+	readinessCurlOpts := []curl.Option{
+		curl.WithHost(pod.Status.PodIP),
+		curl.WithPort(8082),
+		curl.WithPath("/ready"),
+		curl.WithConnectionTimeout(2),
+	}
+	listenerCurlOpts := []curl.Option{
+		curl.WithHost(kubeutils.ServiceFQDN(proxyObjectMeta)),
+		curl.WithHostHeader("example.com"),
+		curl.WithPort(8080),
+		curl.WithConnectionTimeout(2),
+	}
+	s.TestInstallation.Assertions.AssertEventualCurlResponse(
+		s.Ctx,
+		defaults.CurlPodExecOpt,
+		readinessCurlOpts,
+		&testmatchers.HttpResponse{StatusCode: http.StatusOK},
+	)
+
+	// the curl pod is not an allowed peer, so it can reach neither the listener nor the readiness port
+	s.patchGatewayParameters(gwParamsDefaultObjectMeta, func(parameters *kgateway.GatewayParameters) {
+		parameters.Spec.Kube.NetworkPolicyEnabled = ptr.To(true)
+		parameters.Spec.Kube.NetworkPolicyAllowedPeers = []networkingv1.NetworkPolicyPeer{
+			allowedPeer(map[string]string{"app": "allowed"}),
+		}
+	})
+	networkPolicy := &networkingv1.NetworkPolicy{ObjectMeta: proxyObjectMeta}
+	s.TestInstallation.Assertions.EventuallyObjectsExist(s.Ctx, networkPolicy)
+
+	s.TestInstallation.Assertions.AssertEventualCurlError(
+		s.Ctx,
+		defaults.CurlPodExecOpt,
+		listenerCurlOpts,
+		0,
+		30*time.Second,
+	)
+	s.TestInstallation.Assertions.AssertEventualCurlError(
+		s.Ctx,
+		defaults.CurlPodExecOpt,
+		readinessCurlOpts,
+		0,
+		30*time.Second,
+	)
+
+	// once the curl pod is an allowed peer, it can reach the listener but still not the readiness port
+	s.patchGatewayParameters(gwParamsDefaultObjectMeta, func(parameters *kgateway.GatewayParameters) {
+		parameters.Spec.Kube.NetworkPolicyAllowedPeers = []networkingv1.NetworkPolicyPeer{
+			allowedPeer(map[string]string{"app": "curl"}),
+		}
+	})
+	s.TestInstallation.Assertions.AssertEventuallyConsistentCurlResponse(
+		s.Ctx,
+		defaults.CurlPodExecOpt,
+		listenerCurlOpts,
+		&testmatchers.HttpResponse{StatusCode: http.StatusOK},
+	)
+	s.TestInstallation.Assertions.AssertEventualCurlError(
+		s.Ctx,
+		defaults.CurlPodExecOpt,
+		readinessCurlOpts,
+		0,
+		30*time.Second,
+	)
+
+	s.patchGatewayParameters(gwParamsDefaultObjectMeta, func(parameters *kgateway.GatewayParameters) {
+		parameters.Spec.Kube.NetworkPolicyEnabled = ptr.To(false)
+		parameters.Spec.Kube.NetworkPolicyAllowedPeers = nil
+	})
+	s.TestInstallation.Assertions.EventuallyObjectsNotExist(s.Ctx, networkPolicy)
+}
+
+// allowedPeer returns a NetworkPolicyPeer which selects the pods with the given labels in any namespace
+func allowedPeer(podLabels map[string]string) networkingv1.NetworkPolicyPeer {
+	return networkingv1.NetworkPolicyPeer{
+		NamespaceSelector: &metav1.LabelSelector{},
+		PodSelector:       &metav1.LabelSelector{MatchLabels: podLabels},
+	}
+}
+
 // patchGateway accepts a reference to an object, and a patch function. It then queries the object,
 // performs the patch in memory, and writes the object back to the cluster.
 func (s *testingSuite) patchGateway(objectMeta metav1.ObjectMeta, patchFn func(*gwv1.Gateway)) {
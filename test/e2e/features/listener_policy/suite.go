@@ -67,6 +67,7 @@ func (s *testingSuite) SetupSuite() {
 		"TestHttpListenerPolicyClearStaleStatus": {gatewayManifest, httpRouteManifest, serverHeaderManifest},
 		"TestEarlyRequestHeaderModifier":         {gatewayManifest, earlyHeaderMutationManifest},
 		"TestProxyProtocol":                      {gatewayManifest, httpRouteManifest, proxyProtocolManifest},
+		"TestErrorResponses":                     {gatewayManifest, httpRouteManifest, errorResponsesManifest},
 		// RequestID configuration tests for the new RequestID feature
 		// These tests use an echo server to verify x-request-id header behavior
 		"TestListenerPolicyRequestId":     {gatewayManifest, requestIdEchoManifest, listenerPolicyRequestIdManifest},
@@ -165,6 +166,38 @@ func (s *testingSuite) TestHttpListenerPolicyServerHeader() {
 		})
 }
 
+func (s *testingSuite) TestErrorResponses() {
+	// Requests for a host without a matching route are answered by Envoy with a 404,
+	// whose body is replaced by the configured error response
+	s.testInstallation.Assertions.AssertEventualCurlResponse(
+		s.ctx,
+		testdefaults.CurlPodExecOpt,
+		[]curl.Option{
+			curl.WithHost(kubeutils.ServiceFQDN(proxyService.ObjectMeta)),
+			curl.WithHostHeader("unmatched.example.com"),
+		},
+		&matchers.HttpResponse{
+			StatusCode: http.StatusNotFound,
+			Body:       gomega.MatchJSON(`{"code": 404, "message": ""}`),
+			Headers: map[string]any{
+				"content-type": "application/json",
+			},
+		})
+
+	// Responses from the backend are left untouched
+	s.testInstallation.Assertions.AssertEventualCurlResponse(
+		s.ctx,
+		testdefaults.CurlPodExecOpt,
+		[]curl.Option{
+			curl.WithHost(kubeutils.ServiceFQDN(proxyService.ObjectMeta)),
+			curl.WithHostHeader("example.com"),
+		},
+		&matchers.HttpResponse{
+			StatusCode: http.StatusOK,
+			Body:       gomega.ContainSubstring("Welcome to nginx!"),
+		})
+}
+
 func (s *testingSuite) TestPreserveHttp1HeaderCase() {
 	// The test verifies that the HTTP1 headers are preserved as expected in the request and response
 	// The HTTPListenerPolicy ensures that the header is preserved in the request,
@@ -0,0 +1,491 @@
+//go:build e2e
+
+package assertions
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	. "github.com/onsi/gomega"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/utils/requestutils/curl"
+	"github.com/kgateway-dev/kgateway/v2/test/gomega/matchers"
+	"github.com/kgateway-dev/kgateway/v2/test/helpers"
+)
+
+// AssertEventualCurlReturnResponseNative asserts that a native curl request, executed from the test runner,
+// eventually returns the expected response. The returned response has its body buffered so that it can still be read,
+// and the caller is responsible for closing it.
+func (p *Provider) AssertEventualCurlReturnResponseNative(
+	ctx context.Context,
+	curlOptions []curl.Option,
+	expectedResponse *matchers.HttpResponse,
+	timeout ...time.Duration,
+) *http.Response {
+	return p.assertEventualNativeResponse(ctx, func() (*http.Response, error) {
+		return curl.ExecuteRequest(curlOptions...)
+	}, expectedResponse, timeout...)
+}
+
+// AssertEventualCurlResponseNativeWithClient asserts that a native curl request, executed from the test runner
+// using the provided client, eventually returns the expected response.
+// This allows tests to share a connection pool across requests, or to inject a custom transport.
+func (p *Provider) AssertEventualCurlResponseNativeWithClient(
+	ctx context.Context,
+	client *http.Client,
+	curlOptions []curl.Option,
+	expectedResponse *matchers.HttpResponse,
+	timeout ...time.Duration,
+) {
+	resp := p.assertEventualNativeResponse(ctx, func() (*http.Response, error) {
+		return curl.ExecuteRequestWithClient(client, curlOptions...)
+	}, expectedResponse, timeout...)
+	resp.Body.Close()
+}
+
+// assertEventualNativeResponse asserts that the response returned by execute eventually matches the expected response
+func (p *Provider) assertEventualNativeResponse(
+	ctx context.Context,
+	execute func() (*http.Response, error),
+	expectedResponse *matchers.HttpResponse,
+	timeout ...time.Duration,
+) *http.Response {
+	currentTimeout, pollingInterval := helpers.GetTimeouts(timeout...)
+
+	var resp *http.Response
+	var bodyBytes []byte
+	history := &pollHistory{}
+	p.Gomega.Eventually(func(g Gomega) {
+		r, err := execute()
+		if err != nil {
+			history.record("error: %v", err)
+		}
+		g.Expect(err).NotTo(HaveOccurred())
+
+		// Buffer the body so the matcher can consume it while the returned response still has a body.
+		// Fully draining the body also populates the response trailers before they are matched.
+		bodyBytes, err = io.ReadAll(r.Body)
+		r.Body.Close()
+		history.record("status %d, body: %q", r.StatusCode, truncateBody(bodyBytes))
+		g.Expect(err).NotTo(HaveOccurred())
+		r.Body = io.NopCloser(bytes.NewReader(bodyBytes))
+
+		g.Expect(r).To(matchers.HaveHttpResponse(expectedResponse))
+		resp = r
+	}).
+		WithTimeout(currentTimeout).
+		WithPolling(pollingInterval).
+		WithContext(ctx).
+		Should(Succeed(), func() string {
+			return "failed to get expected response, " + history.String()
+		})
+
+	resp.Body = io.NopCloser(bytes.NewReader(bodyBytes))
+	return resp
+}
+
+const (
+	// pollHistorySize is the number of most recent polls reported when a native eventual assertion fails
+	pollHistorySize = 5
+	// pollHistoryBodyLimit is the number of bytes of each response body included in the poll history
+	pollHistoryBodyLimit = 200
+)
+
+// pollHistory keeps the outcome of the most recent polls of an eventual assertion, so that a failure
+// reports what each of them returned rather than only the last error
+type pollHistory struct {
+	polls []string
+	total int
+}
+
+func (h *pollHistory) record(format string, args ...any) {
+	h.total++
+	h.polls = append(h.polls, fmt.Sprintf("poll %d: ", h.total)+fmt.Sprintf(format, args...))
+	if len(h.polls) > pollHistorySize {
+		h.polls = h.polls[1:]
+	}
+}
+
+func (h *pollHistory) String() string {
+	return fmt.Sprintf("last %d of %d polls:\n%s", len(h.polls), h.total, strings.Join(h.polls, "\n"))
+}
+
+// truncateBody returns body, truncated to pollHistoryBodyLimit bytes
+func truncateBody(body []byte) string {
+	if len(body) <= pollHistoryBodyLimit {
+		return string(body)
+	}
+	return string(body[:pollHistoryBodyLimit]) + "...(truncated)"
+}
+
+// AssertEventuallyConsistentCurlResponseNative asserts that a native curl request, executed from the test runner,
+// eventually and then consistently returns the expected response.
+// Every poll of the consistent phase is recorded, and if any diverges from the expected response the assertion fails
+// with the full sequence of observed status codes, so that a flapping route can be told apart from a single failure.
+// The observed status codes are returned, with 0 recorded for polls which failed to receive a response.
+// The timeout, if provided, applies to both the eventual and the consistent phase.
+func (p *Provider) AssertEventuallyConsistentCurlResponseNative(
+	ctx context.Context,
+	curlOptions []curl.Option,
+	expectedResponse *matchers.HttpResponse,
+	timeout ...time.Duration,
+) []int {
+	p.AssertEventualCurlReturnResponseNative(ctx, curlOptions, expectedResponse, timeout...).Body.Close()
+
+	pollTimeout := 3 * time.Second
+	pollInterval := 1 * time.Second
+	if len(timeout) > 0 {
+		pollTimeout, pollInterval = helpers.GetTimeouts(timeout...)
+	}
+
+	statusCodes, failures := observeNativeResponses(ctx, curlOptions, expectedResponse, pollTimeout, pollInterval)
+	p.Gomega.Expect(failures).To(BeEmpty(), "expected a consistent response, observed status codes: %v", statusCodes)
+	return statusCodes
+}
+
+// observeNativeResponses polls with a native curl request until pollTimeout has elapsed, without stopping at the first
+// divergent response. It returns the status code of every poll, and a description of each poll which did not match
+// the expected response.
+func observeNativeResponses(
+	ctx context.Context,
+	curlOptions []curl.Option,
+	expectedResponse *matchers.HttpResponse,
+	pollTimeout, pollInterval time.Duration,
+) (statusCodes []int, failures []string) {
+	deadline := time.Now().Add(pollTimeout)
+	for poll := 1; ; poll++ {
+		resp, err := curl.ExecuteRequestWithContext(ctx, curlOptions...)
+		if err != nil {
+			statusCodes = append(statusCodes, 0)
+			failures = append(failures, fmt.Sprintf("poll %d: %v", poll, err))
+		} else {
+			statusCodes = append(statusCodes, resp.StatusCode)
+			// matchers are single use, so a new one is created for each poll
+			if ok, err := matchers.HaveHttpResponse(expectedResponse).Match(resp); err != nil || !ok {
+				failures = append(failures, fmt.Sprintf("poll %d: unexpected response with status %d", poll, resp.StatusCode))
+			}
+			resp.Body.Close()
+		}
+
+		if time.Now().Add(pollInterval).After(deadline) {
+			return statusCodes, failures
+		}
+		select {
+		case <-ctx.Done():
+			return statusCodes, append(failures, ctx.Err().Error())
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// AssertConcurrentCurlResponsesNative asserts that every one of totalRequests native curl requests, executed from the
+// test runner across concurrency goroutines, returns the expected response. This is useful to catch bugs which only
+// surface under concurrent load, such as state shared across requests corrupting responses.
+// The requests share a single client, so connections are reused as they would be by a real client, and the idle
+// connection pool is sized to the concurrency unless curlOptions configure it via curl.WithConnectionPool.
+// Every request is made even after one diverges, and a failure reports the distribution of observed status codes,
+// with 0 recorded for requests which failed to receive a response.
+func (p *Provider) AssertConcurrentCurlResponsesNative(
+	ctx context.Context,
+	curlOptions []curl.Option,
+	expectedResponse *matchers.HttpResponse,
+	concurrency int,
+	totalRequests int,
+) {
+	p.Require.Positive(concurrency, "concurrency must be positive")
+	p.Require.Positive(totalRequests, "totalRequests must be positive")
+
+	client, err := curl.BuildClient(append([]curl.Option{curl.WithConnectionPool(0, concurrency, 0)}, curlOptions...)...)
+	p.Require.NoError(err)
+	defer client.CloseIdleConnections()
+
+	var (
+		mu          sync.Mutex
+		statusCodes = map[int]int{}
+		failures    []string
+		next        atomic.Int64
+		wg          sync.WaitGroup
+	)
+	record := func(statusCode int, failure string) {
+		mu.Lock()
+		defer mu.Unlock()
+		statusCodes[statusCode]++
+		if failure != "" && len(failures) < pollHistorySize {
+			failures = append(failures, failure)
+		}
+	}
+
+	for range min(concurrency, totalRequests) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := next.Add(1); i <= int64(totalRequests); i = next.Add(1) {
+				if ctx.Err() != nil {
+					record(0, fmt.Sprintf("request %d: %v", i, ctx.Err()))
+					continue
+				}
+				resp, err := curl.ExecuteRequestWithClient(client, curlOptions...)
+				if err != nil {
+					record(0, fmt.Sprintf("request %d: %v", i, err))
+					continue
+				}
+				// matchers are single use, so a new one is created for each request
+				var failure string
+				if ok, err := matchers.HaveHttpResponse(expectedResponse).Match(resp); err != nil || !ok {
+					failure = fmt.Sprintf("request %d: unexpected response with status %d", i, resp.StatusCode)
+				}
+				resp.Body.Close()
+				record(resp.StatusCode, failure)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// only the first few failures are kept, as the distribution already summarizes the rest
+	p.Gomega.Expect(failures).To(BeEmpty(),
+		"expected all %d concurrent requests to return the expected response, observed status code distribution: %s",
+		totalRequests, formatStatusCodeDistribution(statusCodes))
+}
+
+// formatStatusCodeDistribution renders the number of requests observed for each status code, in status code order
+func formatStatusCodeDistribution(statusCodes map[int]int) string {
+	codes := slices.Sorted(maps.Keys(statusCodes))
+	parts := make([]string, 0, len(codes))
+	for _, code := range codes {
+		parts = append(parts, fmt.Sprintf("%d: %d", code, statusCodes[code]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// AssertEventualCurlReturnResponseNativeWithTLS behaves like AssertEventualCurlReturnResponseNative,
+// and additionally returns the state of the TLS connection the response was received on.
+// This can be used to assert on the negotiated TLS version and cipher suite.
+func (p *Provider) AssertEventualCurlReturnResponseNativeWithTLS(
+	ctx context.Context,
+	curlOptions []curl.Option,
+	expectedResponse *matchers.HttpResponse,
+	timeout ...time.Duration,
+) (*http.Response, *tls.ConnectionState) {
+	resp := p.AssertEventualCurlReturnResponseNative(ctx, curlOptions, expectedResponse, timeout...)
+	p.Require.NotNil(resp.TLS, "expected response to be received over a TLS connection")
+	return resp, resp.TLS
+}
+
+// AssertConsistentlyNoResponse asserts that a native curl request, executed from the test runner,
+// consistently fails to receive a successful response. Each attempt must either fail to connect,
+// or return a non-2xx response.
+// If an expected response is provided, each attempt must instead return a non-2xx response matching it,
+// which is useful to assert on a specific error status (e.g. 404 or 503) rather than merely a connectivity failure.
+func (p *Provider) AssertConsistentlyNoResponse(
+	ctx context.Context,
+	curlOptions []curl.Option,
+	expectedResponse *matchers.HttpResponse,
+	timeout ...time.Duration,
+) {
+	pollTimeout := 3 * time.Second
+	pollInterval := 1 * time.Second
+	if len(timeout) > 0 {
+		pollTimeout, pollInterval = helpers.GetTimeouts(timeout...)
+	}
+
+	p.Gomega.Consistently(func(g Gomega) {
+		resp, err := curl.ExecuteRequest(curlOptions...)
+		if err != nil {
+			g.Expect(expectedResponse).To(BeNil(), "expected response %s, got error: %v", expectedResponse, err)
+			return
+		}
+		defer resp.Body.Close()
+
+		g.Expect(resp.StatusCode).NotTo(And(
+			BeNumerically(">=", http.StatusOK),
+			BeNumerically("<", http.StatusMultipleChoices),
+		), "expected no successful response")
+		if expectedResponse != nil {
+			g.Expect(resp).To(matchers.HaveHttpResponse(expectedResponse))
+		}
+	}).
+		WithTimeout(pollTimeout).
+		WithPolling(pollInterval).
+		WithContext(ctx).
+		Should(Succeed())
+}
+
+// AssertConsistentlyCurlErrorNative asserts that a native curl request, executed from the test runner,
+// consistently fails with an error such as a refused connection, as opposed to an http response from the server.
+// Unlike AssertConsistentlyNoResponse, any response fails the assertion, whatever its status code,
+// which is useful to assert that an endpoint is never reachable (e.g. behind a deny-all policy).
+// The status code and body of an unexpected response are included in the failure message.
+func (p *Provider) AssertConsistentlyCurlErrorNative(
+	ctx context.Context,
+	curlOptions []curl.Option,
+	timeout ...time.Duration,
+) {
+	pollTimeout := 3 * time.Second
+	pollInterval := 1 * time.Second
+	if len(timeout) > 0 {
+		pollTimeout, pollInterval = helpers.GetTimeouts(timeout...)
+	}
+
+	p.Gomega.Consistently(func(g Gomega) {
+		resp, err := curl.ExecuteRequestWithContext(ctx, curlOptions...)
+		g.Expect(err).To(HaveOccurred(), func() string {
+			defer resp.Body.Close()
+			body, _ := io.ReadAll(resp.Body)
+			return fmt.Sprintf("expected a curl error, got response with status %d and body: %s", resp.StatusCode, body)
+		})
+	}).
+		WithTimeout(pollTimeout).
+		WithPolling(pollInterval).
+		WithContext(ctx).
+		Should(Succeed())
+}
+
+// AssertEventualCurlLatencyNative asserts that a native curl request, executed from the test runner,
+// eventually returns a successful (2xx) response within maxLatency.
+// Latency is the wall-clock time taken by curl.ExecuteRequest, and is only measured for successful responses,
+// so that fast failures (e.g. while a route is still being programmed) are not mistaken for fast responses.
+func (p *Provider) AssertEventualCurlLatencyNative(
+	ctx context.Context,
+	curlOptions []curl.Option,
+	maxLatency time.Duration,
+	timeout ...time.Duration,
+) {
+	currentTimeout, pollingInterval := helpers.GetTimeouts(timeout...)
+
+	p.Gomega.Eventually(func(g Gomega) {
+		start := time.Now()
+		resp, err := curl.ExecuteRequest(curlOptions...)
+		latency := time.Since(start)
+		g.Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+
+		g.Expect(resp.StatusCode).To(And(
+			BeNumerically(">=", http.StatusOK),
+			BeNumerically("<", http.StatusMultipleChoices),
+		), "expected a successful response")
+		g.Expect(latency).To(BeNumerically("<=", maxLatency),
+			"expected response within %s, observed latency %s", maxLatency, latency)
+	}).
+		WithTimeout(currentTimeout).
+		WithPolling(pollingInterval).
+		WithContext(ctx).
+		Should(Succeed())
+}
+
+// AssertEventualSSEStreamNative asserts that a native curl request, executed from the test runner,
+// eventually returns a Server-Sent Events stream whose first events carry the expected data payloads, in order.
+// The stream is read incrementally rather than buffered, so that a stream which never closes can still be asserted on,
+// and the request is cancelled once the expected events have been received or the timeout is reached.
+func (p *Provider) AssertEventualSSEStreamNative(
+	ctx context.Context,
+	curlOptions []curl.Option,
+	expectedEvents []string,
+	timeout ...time.Duration,
+) {
+	currentTimeout, pollingInterval := helpers.GetTimeouts(timeout...)
+
+	p.Gomega.Eventually(func(g Gomega) {
+		// bound each attempt, as reading from a stalled stream would otherwise block forever
+		reqCtx, cancel := context.WithTimeout(ctx, currentTimeout)
+		defer cancel()
+
+		resp, err := curl.ExecuteRequestWithContext(reqCtx, curlOptions...)
+		g.Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+
+		g.Expect(resp).To(HaveHTTPStatus(http.StatusOK))
+		g.Expect(resp.Header.Get("Content-Type")).To(HavePrefix("text/event-stream"))
+
+		var received []string
+		var data []string
+		scanner := bufio.NewScanner(resp.Body)
+		for len(received) < len(expectedEvents) && scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case line == "":
+				// a blank line dispatches the event, events without data are ignored
+				if data != nil {
+					event := strings.Join(data, "\n")
+					g.Expect(event).To(Equal(expectedEvents[len(received)]),
+						"unexpected event %d, received events so far: %q", len(received)+1, received)
+					received = append(received, event)
+					data = nil
+				}
+			case strings.HasPrefix(line, "data:"):
+				data = append(data, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+			}
+		}
+		g.Expect(scanner.Err()).NotTo(HaveOccurred(), "failed to read stream, received events: %q", received)
+		g.Expect(received).To(HaveLen(len(expectedEvents)), "stream ended before all events were received")
+	}).
+		WithTimeout(currentTimeout).
+		WithPolling(pollingInterval).
+		WithContext(ctx).
+		Should(Succeed(), "failed to receive expected events")
+}
+
+// AssertEventualWebSocketNative asserts that a WebSocket connection to url, established from the test runner,
+// eventually upgrades successfully, and that after sending sendMsgs as text frames, expectRecv are received in order.
+// The curl options are translated into the dialer configuration, so that headers (including Host),
+// TLS, resolution and dialer options apply to the upgrade request as they would to a native curl request.
+func (p *Provider) AssertEventualWebSocketNative(
+	ctx context.Context,
+	url string,
+	curlOptions []curl.Option,
+	sendMsgs, expectRecv []string,
+	timeout ...time.Duration,
+) {
+	currentTimeout, pollingInterval := helpers.GetTimeouts(timeout...)
+
+	transport, header, err := curl.BuildTransport(curlOptions...)
+	p.Require.NoError(err, "invalid curl options")
+	dialer := &websocket.Dialer{
+		NetDialContext:   transport.DialContext,
+		TLSClientConfig:  transport.TLSClientConfig,
+		HandshakeTimeout: currentTimeout,
+	}
+
+	p.Gomega.Eventually(func(g Gomega) {
+		conn, resp, err := dialer.DialContext(ctx, url, header)
+		if resp != nil && resp.Body != nil {
+			resp.Body.Close()
+		}
+		if err != nil && resp != nil {
+			g.Expect(err).NotTo(HaveOccurred(), "websocket upgrade rejected with status %s", resp.Status)
+		}
+		g.Expect(err).NotTo(HaveOccurred(), "failed to establish websocket connection")
+		defer conn.Close()
+
+		for _, msg := range sendMsgs {
+			g.Expect(conn.WriteMessage(websocket.TextMessage, []byte(msg))).To(Succeed())
+		}
+
+		// bound reads, as a missing frame would otherwise block forever
+		g.Expect(conn.SetReadDeadline(time.Now().Add(currentTimeout))).To(Succeed())
+		for i, expected := range expectRecv {
+			_, msg, err := conn.ReadMessage()
+			g.Expect(err).NotTo(HaveOccurred(), "failed to read frame %d", i+1)
+			g.Expect(string(msg)).To(Equal(expected), "unexpected frame %d", i+1)
+		}
+
+		// close cleanly, ignoring errors as the connection is discarded regardless
+		_ = conn.WriteControl(websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(time.Second))
+	}).
+		WithTimeout(currentTimeout).
+		WithPolling(pollingInterval).
+		WithContext(ctx).
+		Should(Succeed(), "failed to exchange websocket messages")
+}
@@ -0,0 +1,666 @@
+//go:build e2e
+
+package assertions
+
+import (
+	"crypto/tls"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/onsi/gomega"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/utils/requestutils/curl"
+	"github.com/kgateway-dev/kgateway/v2/test/gomega/matchers"
+)
+
+func TestAssertConsistentlyNoResponse(t *testing.T) {
+	newServer := func(status int) *httptest.Server {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+		}))
+		t.Cleanup(server.Close)
+		return server
+	}
+	serverOpts := func(server *httptest.Server) []curl.Option {
+		return []curl.Option{curl.WithHostPort(strings.TrimPrefix(server.URL, "http://"))}
+	}
+	closedServerOpts := func() []curl.Option {
+		server := newServer(http.StatusOK)
+		server.Close()
+		return serverOpts(server)
+	}
+
+	testCases := []struct {
+		name             string
+		curlOptions      []curl.Option
+		expectedResponse *matchers.HttpResponse
+		expectFailure    bool
+	}{
+		{
+			name:        "error status without expected response",
+			curlOptions: serverOpts(newServer(http.StatusServiceUnavailable)),
+		},
+		{
+			name:             "error status matching expected response",
+			curlOptions:      serverOpts(newServer(http.StatusServiceUnavailable)),
+			expectedResponse: &matchers.HttpResponse{StatusCode: http.StatusServiceUnavailable},
+		},
+		{
+			name:             "error status not matching expected response",
+			curlOptions:      serverOpts(newServer(http.StatusServiceUnavailable)),
+			expectedResponse: &matchers.HttpResponse{StatusCode: http.StatusNotFound},
+			expectFailure:    true,
+		},
+		{
+			name:          "successful response",
+			curlOptions:   serverOpts(newServer(http.StatusOK)),
+			expectFailure: true,
+		},
+		{
+			name:        "connection failure without expected response",
+			curlOptions: closedServerOpts(),
+		},
+		{
+			name:             "connection failure with expected response",
+			curlOptions:      closedServerOpts(),
+			expectedResponse: &matchers.HttpResponse{StatusCode: http.StatusServiceUnavailable},
+			expectFailure:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var failure string
+			p := NewProvider(t)
+			p.Gomega = gomega.NewGomega(func(message string, _ ...int) {
+				failure = message
+			})
+
+			p.AssertConsistentlyNoResponse(t.Context(), tc.curlOptions, tc.expectedResponse, 200*time.Millisecond, 50*time.Millisecond)
+
+			if tc.expectFailure && failure == "" {
+				t.Fatal("expected assertion to fail")
+			}
+			if !tc.expectFailure && failure != "" {
+				t.Fatalf("expected assertion to succeed, got: %s", failure)
+			}
+		})
+	}
+}
+
+func TestAssertConsistentlyCurlErrorNative(t *testing.T) {
+	newServer := func(status int) *httptest.Server {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+			io.WriteString(w, "leaked") //nolint:errcheck
+		}))
+		t.Cleanup(server.Close)
+		return server
+	}
+	serverOpts := func(server *httptest.Server) []curl.Option {
+		return []curl.Option{curl.WithHostPort(strings.TrimPrefix(server.URL, "http://"))}
+	}
+	closedServer := newServer(http.StatusOK)
+	closedServer.Close()
+
+	testCases := []struct {
+		name            string
+		curlOptions     []curl.Option
+		expectedFailure string
+	}{
+		{
+			name:        "connection failure",
+			curlOptions: serverOpts(closedServer),
+		},
+		{
+			name:            "successful response",
+			curlOptions:     serverOpts(newServer(http.StatusOK)),
+			expectedFailure: "got response with status 200 and body: leaked",
+		},
+		{
+			name:            "error status",
+			curlOptions:     serverOpts(newServer(http.StatusForbidden)),
+			expectedFailure: "got response with status 403 and body: leaked",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var failure string
+			p := NewProvider(t)
+			p.Gomega = gomega.NewGomega(func(message string, _ ...int) {
+				failure = message
+			})
+
+			p.AssertConsistentlyCurlErrorNative(t.Context(), tc.curlOptions, 200*time.Millisecond, 50*time.Millisecond)
+
+			if tc.expectedFailure == "" && failure != "" {
+				t.Fatalf("expected assertion to succeed, got: %s", failure)
+			}
+			if !strings.Contains(failure, tc.expectedFailure) {
+				t.Fatalf("expected failure containing %q, got: %s", tc.expectedFailure, failure)
+			}
+		})
+	}
+}
+
+func TestAssertEventualCurlReturnResponseNativeWithTLS(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("hello"))
+	}))
+	server.TLS = &tls.Config{MinVersion: tls.VersionTLS13}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	p := NewProvider(t)
+	resp, state := p.AssertEventualCurlReturnResponseNativeWithTLS(t.Context(),
+		[]curl.Option{
+			curl.WithScheme("https"),
+			curl.WithHostPort(strings.TrimPrefix(server.URL, "https://")),
+			curl.IgnoreServerCert(),
+		},
+		&matchers.HttpResponse{StatusCode: http.StatusOK, Body: "hello"},
+		time.Second, 100*time.Millisecond,
+	)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read body: %v", err)
+	}
+	if string(body) != "hello" {
+		t.Fatalf("expected body %q, got %q", "hello", body)
+	}
+	if state.Version != tls.VersionTLS13 {
+		t.Fatalf("expected TLS 1.3, got %s", tls.VersionName(state.Version))
+	}
+}
+
+func TestAssertEventualCurlResponseNativeWithClient(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// fail the first request, so that the assertion has to retry
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("hello"))
+	}))
+	t.Cleanup(server.Close)
+
+	var userAgent atomic.Value
+	client := &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		userAgent.Store(req.Header.Get("User-Agent"))
+		return http.DefaultTransport.RoundTrip(req)
+	})}
+
+	p := NewProvider(t)
+	p.AssertEventualCurlResponseNativeWithClient(t.Context(), client,
+		[]curl.Option{
+			curl.WithHostPort(strings.TrimPrefix(server.URL, "http://")),
+			curl.WithHeader("User-Agent", "custom-client"),
+		},
+		&matchers.HttpResponse{StatusCode: http.StatusOK, Body: "hello"},
+		time.Second, 10*time.Millisecond,
+	)
+
+	if got := requests.Load(); got != 2 {
+		t.Fatalf("expected 2 requests, got %d", got)
+	}
+	if got := userAgent.Load(); got != "custom-client" {
+		t.Fatalf("expected requests to be executed by the provided client, got User-Agent %v", got)
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestAssertEventualCurlLatencyNative(t *testing.T) {
+	newServer := func(status int, delay time.Duration) []curl.Option {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(delay)
+			w.WriteHeader(status)
+		}))
+		t.Cleanup(server.Close)
+		return []curl.Option{curl.WithHostPort(strings.TrimPrefix(server.URL, "http://"))}
+	}
+
+	testCases := []struct {
+		name            string
+		curlOptions     []curl.Option
+		expectedFailure string
+	}{
+		{
+			name:        "fast successful response",
+			curlOptions: newServer(http.StatusOK, 0),
+		},
+		{
+			name:            "slow successful response",
+			curlOptions:     newServer(http.StatusOK, 200*time.Millisecond),
+			expectedFailure: "observed latency",
+		},
+		{
+			name:            "fast error response",
+			curlOptions:     newServer(http.StatusServiceUnavailable, 0),
+			expectedFailure: "expected a successful response",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var failure string
+			p := NewProvider(t)
+			p.Gomega = gomega.NewGomega(func(message string, _ ...int) {
+				failure = message
+			})
+
+			p.AssertEventualCurlLatencyNative(t.Context(), tc.curlOptions, 100*time.Millisecond, 500*time.Millisecond, 50*time.Millisecond)
+
+			if tc.expectedFailure == "" && failure != "" {
+				t.Fatalf("expected assertion to succeed, got: %s", failure)
+			}
+			if tc.expectedFailure != "" && !strings.Contains(failure, tc.expectedFailure) {
+				t.Fatalf("expected assertion to fail with %q, got: %q", tc.expectedFailure, failure)
+			}
+		})
+	}
+}
+
+func TestAssertEventualSSEStreamNative(t *testing.T) {
+	// newServer returns options for a server which streams the provided raw events, and then either closes the stream
+	// or holds it open until the request is cancelled. The returned counter tracks the number of in-flight requests.
+	newServer := func(events []string, closeStream bool) ([]curl.Option, *atomic.Int32) {
+		var inFlight atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			inFlight.Add(1)
+			defer inFlight.Add(-1)
+			w.Header().Set("Content-Type", "text/event-stream")
+			for _, event := range events {
+				io.WriteString(w, event)
+				w.(http.Flusher).Flush()
+				time.Sleep(10 * time.Millisecond)
+			}
+			if !closeStream {
+				<-r.Context().Done()
+			}
+		}))
+		t.Cleanup(server.Close)
+		return []curl.Option{curl.WithHostPort(strings.TrimPrefix(server.URL, "http://"))}, &inFlight
+	}
+
+	testCases := []struct {
+		name            string
+		events          []string
+		closeStream     bool
+		expectedFailure string
+	}{
+		{
+			name:   "events arrive in order on an open stream",
+			events: []string{": keep-alive\n\n", "event: greeting\ndata: hello\n\n", "data: multi\ndata: line\n\n", "data: ignored\n\n"},
+		},
+		{
+			name:        "events arrive in order before the stream closes",
+			events:      []string{"data: hello\n\n", "data: multi\ndata: line\n\n"},
+			closeStream: true,
+		},
+		{
+			name:            "events arrive out of order",
+			events:          []string{"data: multi\ndata: line\n\n", "data: hello\n\n"},
+			expectedFailure: "unexpected event 1",
+		},
+		{
+			name:            "stream closes before all events arrive",
+			events:          []string{"data: hello\n\n"},
+			closeStream:     true,
+			expectedFailure: "stream ended before all events were received",
+		},
+		{
+			name:            "stream stalls before all events arrive",
+			events:          []string{"data: hello\n\n"},
+			expectedFailure: "failed to read stream",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			curlOptions, inFlight := newServer(tc.events, tc.closeStream)
+
+			var failure string
+			p := NewProvider(t)
+			p.Gomega = gomega.NewGomega(func(message string, _ ...int) {
+				failure = message
+			})
+
+			p.AssertEventualSSEStreamNative(t.Context(), curlOptions, []string{"hello", "multi\nline"}, 500*time.Millisecond, 50*time.Millisecond)
+
+			if tc.expectedFailure == "" && failure != "" {
+				t.Fatalf("expected assertion to succeed, got: %s", failure)
+			}
+			if tc.expectedFailure != "" && !strings.Contains(failure, tc.expectedFailure) {
+				t.Fatalf("expected assertion to fail with %q, got: %q", tc.expectedFailure, failure)
+			}
+
+			// the request must be cancelled once the assertion returns, even if the stream is still open
+			deadline := time.Now().Add(2 * time.Second)
+			for inFlight.Load() > 0 {
+				if time.Now().After(deadline) {
+					t.Fatal("expected the streaming request to be cancelled")
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+		})
+	}
+}
+
+func TestAssertEventualWebSocketNative(t *testing.T) {
+	var host, testHeader atomic.Value
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/reject" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		host.Store(r.Host)
+		testHeader.Store(r.Header.Get("x-test"))
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		// echo frames until the client closes the connection
+		for {
+			messageType, msg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if err := conn.WriteMessage(messageType, msg); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	serverHost, serverPort, err := net.SplitHostPort(strings.TrimPrefix(server.URL, "http://"))
+	if err != nil {
+		t.Fatalf("failed to parse server address: %v", err)
+	}
+	port, err := strconv.Atoi(serverPort)
+	if err != nil {
+		t.Fatalf("failed to parse server port: %v", err)
+	}
+	wsURL := "ws://" + net.JoinHostPort(serverHost, serverPort)
+
+	testCases := []struct {
+		name            string
+		url             string
+		curlOptions     []curl.Option
+		expectRecv      []string
+		expectedFailure string
+	}{
+		{
+			name:       "frames are echoed",
+			url:        wsURL + "/echo",
+			expectRecv: []string{"hello", "world"},
+		},
+		{
+			name:            "unexpected frame",
+			url:             wsURL + "/echo",
+			expectRecv:      []string{"world", "hello"},
+			expectedFailure: "unexpected frame 1",
+		},
+		{
+			name:            "missing frame",
+			url:             wsURL + "/echo",
+			expectRecv:      []string{"hello", "world", "again"},
+			expectedFailure: "failed to read frame 3",
+		},
+		{
+			name:            "upgrade rejected",
+			url:             wsURL + "/reject",
+			expectRecv:      []string{"hello", "world"},
+			expectedFailure: "websocket upgrade rejected with status 403 Forbidden",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var failure string
+			p := NewProvider(t)
+			p.Gomega = gomega.NewGomega(func(message string, _ ...int) {
+				failure = message
+			})
+
+			p.AssertEventualWebSocketNative(t.Context(), tc.url, tc.curlOptions, []string{"hello", "world"}, tc.expectRecv, 500*time.Millisecond, 50*time.Millisecond)
+
+			if tc.expectedFailure == "" && failure != "" {
+				t.Fatalf("expected assertion to succeed, got: %s", failure)
+			}
+			if tc.expectedFailure != "" && !strings.Contains(failure, tc.expectedFailure) {
+				t.Fatalf("expected assertion to fail with %q, got: %q", tc.expectedFailure, failure)
+			}
+		})
+	}
+
+	t.Run("curl options apply to the upgrade request", func(t *testing.T) {
+		p := NewProvider(t)
+		p.AssertEventualWebSocketNative(t.Context(), "ws://echo.invalid:"+serverPort+"/echo",
+			[]curl.Option{
+				curl.WithResolve("echo.invalid", port, serverHost),
+				curl.WithHostHeader("example.com"),
+				curl.WithHeader("x-test", "value"),
+			},
+			[]string{"hello"}, []string{"hello"}, 500*time.Millisecond, 50*time.Millisecond)
+
+		if got := host.Load(); got != "example.com" {
+			t.Fatalf("expected Host %q, got %v", "example.com", got)
+		}
+		if got := testHeader.Load(); got != "value" {
+			t.Fatalf("expected x-test header %q, got %v", "value", got)
+		}
+	})
+}
+
+func TestAssertConcurrentCurlResponsesNative(t *testing.T) {
+	// newServer returns options for a server which responds with 503 to every failEvery'th request, or never if 0,
+	// along with a counter of the connections it accepted
+	newServer := func(failEvery int32) ([]curl.Option, *atomic.Int32) {
+		var requests, conns atomic.Int32
+		server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if n := requests.Add(1); failEvery > 0 && n%failEvery == 0 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		}))
+		server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+			if state == http.StateNew {
+				conns.Add(1)
+			}
+		}
+		server.Start()
+		t.Cleanup(server.Close)
+		return []curl.Option{curl.WithHostPort(strings.TrimPrefix(server.URL, "http://"))}, &conns
+	}
+
+	testCases := []struct {
+		name            string
+		failEvery       int32
+		expectedFailure string
+	}{
+		{
+			name: "all responses match",
+		},
+		{
+			name:            "some responses diverge",
+			failEvery:       10,
+			expectedFailure: "observed status code distribution: 200: 45, 503: 5",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var failure string
+			p := NewProvider(t)
+			p.Gomega = gomega.NewGomega(func(message string, _ ...int) {
+				failure = message
+			})
+			curlOptions, conns := newServer(tc.failEvery)
+
+			p.AssertConcurrentCurlResponsesNative(t.Context(), curlOptions,
+				&matchers.HttpResponse{StatusCode: http.StatusOK}, 5, 50)
+
+			if tc.expectedFailure == "" && failure != "" {
+				t.Fatalf("expected assertion to succeed, got: %s", failure)
+			}
+			if tc.expectedFailure != "" && !strings.Contains(failure, tc.expectedFailure) {
+				t.Fatalf("expected assertion to fail with %q, got: %q", tc.expectedFailure, failure)
+			}
+			// the client is shared, so connections are reused rather than opened for each request
+			if n := conns.Load(); n > 5 {
+				t.Fatalf("expected at most 5 connections, got %d", n)
+			}
+		})
+	}
+}
+
+func TestAssertEventuallyConsistentCurlResponseNative(t *testing.T) {
+	// newServer returns options for a server which responds with the provided status codes in turn,
+	// repeating the last one once they are exhausted
+	newServer := func(statusCodes ...int) []curl.Option {
+		var requests atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			i := min(int(requests.Add(1))-1, len(statusCodes)-1)
+			w.WriteHeader(statusCodes[i])
+		}))
+		t.Cleanup(server.Close)
+		return []curl.Option{curl.WithHostPort(strings.TrimPrefix(server.URL, "http://"))}
+	}
+
+	testCases := []struct {
+		name                string
+		curlOptions         []curl.Option
+		expectedStatusCodes []int
+		expectedFailure     string
+	}{
+		{
+			name:                "stable",
+			curlOptions:         newServer(http.StatusOK),
+			expectedStatusCodes: []int{200, 200, 200},
+		},
+		{
+			name:                "flapping",
+			curlOptions:         newServer(http.StatusOK, http.StatusOK, http.StatusServiceUnavailable, http.StatusOK),
+			expectedStatusCodes: []int{200, 503, 200},
+			expectedFailure:     "observed status codes: [200 503 200",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var failure string
+			p := NewProvider(t)
+			p.Gomega = gomega.NewGomega(func(message string, _ ...int) {
+				failure = message
+			})
+
+			statusCodes := p.AssertEventuallyConsistentCurlResponseNative(t.Context(), tc.curlOptions,
+				&matchers.HttpResponse{StatusCode: http.StatusOK}, 450*time.Millisecond, 100*time.Millisecond)
+
+			// the exact number of polls depends on timing, so only the first few are compared
+			if len(statusCodes) < len(tc.expectedStatusCodes) ||
+				!slices.Equal(statusCodes[:len(tc.expectedStatusCodes)], tc.expectedStatusCodes) {
+				t.Fatalf("expected status codes %v, got %v", tc.expectedStatusCodes, statusCodes)
+			}
+			if tc.expectedFailure == "" && failure != "" {
+				t.Fatalf("expected assertion to succeed, got: %s", failure)
+			}
+			if tc.expectedFailure != "" && !strings.Contains(failure, tc.expectedFailure) {
+				t.Fatalf("expected assertion to fail with %q, got: %q", tc.expectedFailure, failure)
+			}
+		})
+	}
+}
+
+func TestAssertEventualCurlReturnResponseNativeTrailers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Trailer", "Grpc-Status")
+		w.Write([]byte("hello"))
+		w.Header().Set("Grpc-Status", "0")
+	}))
+	t.Cleanup(server.Close)
+
+	p := NewProvider(t)
+	resp := p.AssertEventualCurlReturnResponseNative(t.Context(),
+		[]curl.Option{curl.WithHostPort(strings.TrimPrefix(server.URL, "http://"))},
+		&matchers.HttpResponse{
+			StatusCode: http.StatusOK,
+			Body:       "hello",
+			Trailers:   map[string]any{"grpc-status": "0"},
+		},
+		time.Second, 100*time.Millisecond,
+	)
+	defer resp.Body.Close()
+
+	if got := resp.Trailer.Get("Grpc-Status"); got != "0" {
+		t.Fatalf("expected grpc-status trailer %q, got %q", "0", got)
+	}
+}
+
+func TestAssertEventualCurlReturnResponseNativePollHistory(t *testing.T) {
+	var polls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := polls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		io.WriteString(w, "attempt "+strconv.Itoa(int(n))+" "+strings.Repeat("x", pollHistoryBodyLimit)) //nolint:errcheck
+	}))
+	t.Cleanup(server.Close)
+
+	var failure string
+	p := NewProvider(t)
+	p.Gomega = gomega.NewGomega(func(message string, _ ...int) {
+		failure = message
+		// stop the assertion like a failing test would
+		runtime.Goexit()
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		p.AssertEventualCurlReturnResponseNative(
+			t.Context(),
+			[]curl.Option{curl.WithHostPort(strings.TrimPrefix(server.URL, "http://"))},
+			&matchers.HttpResponse{StatusCode: http.StatusOK},
+			300*time.Millisecond, 20*time.Millisecond,
+		)
+	}()
+	<-done
+
+	total := int(polls.Load())
+	if total <= pollHistorySize {
+		t.Fatalf("expected more than %d polls, got %d", pollHistorySize, total)
+	}
+	expected := []string{
+		"last " + strconv.Itoa(pollHistorySize) + " of " + strconv.Itoa(total) + " polls",
+		"poll " + strconv.Itoa(total) + ": status 503, body: \"attempt " + strconv.Itoa(total) + " ",
+		"...(truncated)",
+	}
+	for _, e := range expected {
+		if !strings.Contains(failure, e) {
+			t.Fatalf("expected failure to contain %q, got: %s", e, failure)
+		}
+	}
+	if strings.Contains(failure, "poll 1:") {
+		t.Fatalf("expected only the last %d polls, got: %s", pollHistorySize, failure)
+	}
+}
@@ -0,0 +1,82 @@
+//go:build e2e
+
+package assertions
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/utils/requestutils/curl"
+	"github.com/kgateway-dev/kgateway/v2/test/helpers"
+)
+
+// AssertEventualGrpcResponseNative asserts that a unary gRPC call to service/method, made from the test runner
+// to the provided address, eventually returns the expected response.
+// The curl options are translated into the connection configuration: a Host header sets the :authority,
+// other headers are sent as metadata, and TLS, resolution (e.g. curl.WithResolve to pin a hostname to a
+// LoadBalancer IP) and dialer options apply to the connection as they would to a native curl request.
+// Failed calls are reported with their gRPC status code and message.
+func (p *Provider) AssertEventualGrpcResponseNative(
+	ctx context.Context,
+	addr string,
+	curlOptions []curl.Option,
+	service, method string,
+	reqProto, expectedProto proto.Message,
+	timeout ...time.Duration,
+) {
+	currentTimeout, pollingInterval := helpers.GetTimeouts(timeout...)
+
+	transport, header, err := curl.BuildTransport(curlOptions...)
+	p.Require.NoError(err, "invalid curl options")
+
+	dialOpts := []grpc.DialOption{
+		grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+			return transport.DialContext(ctx, "tcp", addr)
+		}),
+	}
+	if transport.TLSClientConfig != nil {
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(credentials.NewTLS(transport.TLSClientConfig)))
+	} else {
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+	if authority := header.Get("Host"); authority != "" {
+		dialOpts = append(dialOpts, grpc.WithAuthority(authority))
+	}
+	header.Del("Host")
+	md := metadata.MD{}
+	for key, values := range header {
+		md.Append(key, values...)
+	}
+
+	// the passthrough resolver hands the address to the dialer unchanged, so that resolution options apply to it
+	conn, err := grpc.NewClient("passthrough:///"+addr, dialOpts...)
+	p.Require.NoError(err, "failed to create gRPC client")
+	defer conn.Close()
+
+	fullMethod := "/" + service + "/" + method
+	p.Gomega.Eventually(func(g Gomega) {
+		resp := expectedProto.ProtoReflect().New().Interface()
+		err := conn.Invoke(metadata.NewOutgoingContext(ctx, md), fullMethod, reqProto, resp)
+		if err != nil {
+			st := status.Convert(err)
+			g.Expect(err).NotTo(HaveOccurred(), "%s failed with code %s: %s", fullMethod, st.Code(), st.Message())
+		}
+		g.Expect(proto.Equal(resp, expectedProto)).To(BeTrue(),
+			"unexpected response from %s (-expected +actual):\n%s", fullMethod, cmp.Diff(expectedProto, resp, protocmp.Transform()))
+	}).
+		WithTimeout(currentTimeout).
+		WithPolling(pollingInterval).
+		WithContext(ctx).
+		Should(Succeed(), "failed to get expected gRPC response")
+}
@@ -0,0 +1,121 @@
+//go:build e2e
+
+package assertions
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/utils/requestutils/curl"
+)
+
+func TestAssertEventualGrpcResponseNative(t *testing.T) {
+	var authority, testHeader atomic.Value
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("serving", healthpb.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus("not-serving", healthpb.HealthCheckResponse_NOT_SERVING)
+	server := grpc.NewServer(grpc.UnaryInterceptor(
+		func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			md, _ := metadata.FromIncomingContext(ctx)
+			authority.Store(strings.Join(md.Get(":authority"), ","))
+			testHeader.Store(strings.Join(md.Get("x-test"), ","))
+			return handler(ctx, req)
+		},
+	))
+	healthpb.RegisterHealthServer(server, healthServer)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	addr := lis.Addr().String()
+	host, portStr, err := net.SplitHostPort(addr)
+	if err != nil {
+		t.Fatalf("failed to parse address: %v", err)
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		t.Fatalf("failed to parse port: %v", err)
+	}
+
+	testCases := []struct {
+		name            string
+		service         string
+		expectedFailure string
+	}{
+		{
+			name:    "expected response",
+			service: "serving",
+		},
+		{
+			name:            "unexpected response",
+			service:         "not-serving",
+			expectedFailure: "unexpected response from /grpc.health.v1.Health/Check",
+		},
+		{
+			name:            "error status",
+			service:         "unknown",
+			expectedFailure: "/grpc.health.v1.Health/Check failed with code NotFound",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var failure string
+			p := NewProvider(t)
+			p.Gomega = gomega.NewGomega(func(message string, _ ...int) {
+				failure = message
+			})
+
+			p.AssertEventualGrpcResponseNative(t.Context(), addr, nil,
+				"grpc.health.v1.Health", "Check",
+				&healthpb.HealthCheckRequest{Service: tc.service},
+				&healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING},
+				300*time.Millisecond, 50*time.Millisecond,
+			)
+
+			if tc.expectedFailure == "" && failure != "" {
+				t.Fatalf("expected assertion to succeed, got: %s", failure)
+			}
+			if tc.expectedFailure != "" && !strings.Contains(failure, tc.expectedFailure) {
+				t.Fatalf("expected assertion to fail with %q, got: %q", tc.expectedFailure, failure)
+			}
+		})
+	}
+
+	t.Run("curl options apply to the connection", func(t *testing.T) {
+		p := NewProvider(t)
+		p.AssertEventualGrpcResponseNative(t.Context(), "grpc.invalid:"+portStr,
+			[]curl.Option{
+				curl.WithResolve("grpc.invalid", port, host),
+				curl.WithHostHeader("example.com"),
+				curl.WithHeader("x-test", "value"),
+			},
+			"grpc.health.v1.Health", "Check",
+			&healthpb.HealthCheckRequest{Service: "serving"},
+			&healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING},
+			time.Second, 50*time.Millisecond,
+		)
+
+		if got := authority.Load(); got != "example.com" {
+			t.Fatalf("expected authority %q, got %v", "example.com", got)
+		}
+		if got := testHeader.Load(); got != "value" {
+			t.Fatalf("expected x-test metadata %q, got %v", "value", got)
+		}
+	})
+}
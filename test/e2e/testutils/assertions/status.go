@@ -5,13 +5,18 @@ package assertions
 import (
 	"context"
 	"fmt"
+	"net"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/onsi/ginkgo/v2"
 	"github.com/onsi/gomega"
 	"github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	inf "sigs.k8s.io/gateway-api-inference-extension/api/v1"
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 	gwv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
@@ -23,28 +28,147 @@ import (
 	"github.com/kgateway-dev/kgateway/v2/test/helpers"
 )
 
-// EventuallyGatewayAddress asserts that eventually at least one of the HTTPRoute's route parent statuses contains
-// the given message substring.
+// gatewayAddressTimeout is how long EventuallyGatewayAddress and EventuallyAllGatewayAddresses wait for
+// the Gateway to be assigned an address
+const gatewayAddressTimeout = 2 * time.Minute
+
+// EventuallyGatewayAddress waits for the Gateway to report an address in its status, and returns it.
+// If prefer is provided, it waits for an address of one of the given types, checked in order.
+// Otherwise, it returns the first IPAddress, falling back to the first address of any type.
 func (p *Provider) EventuallyGatewayAddress(
 	ctx context.Context,
 	gatewayName string,
 	gatewayNamespace string,
-	timeout ...time.Duration,
+	prefer ...gwv1.AddressType,
 ) string {
-	currentTimeout, pollingInterval := helpers.GetTimeouts(timeout...)
 	var addr string
 	p.Gomega.Eventually(func(g gomega.Gomega) {
-		gw := &gwv1.Gateway{}
-		err := p.clusterContext.Client.Get(ctx, types.NamespacedName{Name: gatewayName, Namespace: gatewayNamespace}, gw)
-		g.Expect(err).NotTo(gomega.HaveOccurred(), "can get gateway")
-		if len(gw.Status.Addresses) == 0 {
-			g.Expect(true).To(gomega.BeFalse(), "gateway is not ready")
+		addresses := p.getGatewayAddresses(ctx, g, gatewayName, gatewayNamespace)
+		var found bool
+		addr, found = preferredGatewayAddress(addresses, prefer)
+		g.Expect(found).To(gomega.BeTrue(), fmt.Sprintf("gateway has no address of type %v: %+v", prefer, addresses))
+	}, gatewayAddressTimeout, helpers.DefaultPollingInterval).Should(gomega.Succeed())
+	return addr
+}
+
+// EventuallyAllGatewayAddresses waits for the Gateway to report at least one address in its status,
+// and returns all of them.
+func (p *Provider) EventuallyAllGatewayAddresses(
+	ctx context.Context,
+	gatewayName string,
+	gatewayNamespace string,
+) []gwv1.GatewayStatusAddress {
+	var addresses []gwv1.GatewayStatusAddress
+	p.Gomega.Eventually(func(g gomega.Gomega) {
+		addresses = p.getGatewayAddresses(ctx, g, gatewayName, gatewayNamespace)
+	}, gatewayAddressTimeout, helpers.DefaultPollingInterval).Should(gomega.Succeed())
+	return addresses
+}
+
+func (p *Provider) getGatewayAddresses(
+	ctx context.Context,
+	g gomega.Gomega,
+	gatewayName string,
+	gatewayNamespace string,
+) []gwv1.GatewayStatusAddress {
+	gw := &gwv1.Gateway{}
+	err := p.clusterContext.Client.Get(ctx, types.NamespacedName{Name: gatewayName, Namespace: gatewayNamespace}, gw)
+	g.Expect(err).NotTo(gomega.HaveOccurred(), "can get gateway")
+	g.Expect(gw.Status.Addresses).NotTo(gomega.BeEmpty(), "gateway is not ready")
+	return gw.Status.Addresses
+}
+
+// preferredGatewayAddress returns the value of the first address matching the preferred types, checked in order.
+// With no preference, IPAddress is preferred, but any address is accepted.
+func preferredGatewayAddress(addresses []gwv1.GatewayStatusAddress, prefer []gwv1.AddressType) (string, bool) {
+	strict := len(prefer) > 0
+	if !strict {
+		prefer = []gwv1.AddressType{gwv1.IPAddressType}
+	}
+	for _, t := range prefer {
+		for _, a := range addresses {
+			// an unset type means IPAddress
+			if ptr.Deref(a.Type, gwv1.IPAddressType) == t {
+				return a.Value, true
+			}
 		}
-		addr = gw.Status.Addresses[0].Value
-	}, currentTimeout, pollingInterval).Should(gomega.Succeed())
+	}
+	if !strict && len(addresses) > 0 {
+		return addresses[0].Value, true
+	}
+	return "", false
+}
+
+// EventuallyGatewayNodePortAddress waits for a Ready node, and returns the host:port the test runner can use to reach
+// the given port of a gateway Service of type NodePort, for clusters without a load balancer (e.g. kind).
+// The node's ExternalIP is preferred, falling back to its InternalIP.
+// It fails immediately if the Service is not of type NodePort or does not expose the port.
+func (p *Provider) EventuallyGatewayNodePortAddress(
+	ctx context.Context,
+	serviceName string,
+	serviceNamespace string,
+	port int32,
+) string {
+	var addr string
+	p.Gomega.Eventually(func(g gomega.Gomega) {
+		svc := &corev1.Service{}
+		err := p.clusterContext.Client.Get(ctx, types.NamespacedName{Name: serviceName, Namespace: serviceNamespace}, svc)
+		g.Expect(err).NotTo(gomega.HaveOccurred(), "can get gateway service")
+		if svc.Spec.Type != corev1.ServiceTypeNodePort {
+			gomega.StopTrying(fmt.Sprintf("gateway service %s/%s is of type %s, not NodePort", serviceNamespace, serviceName, svc.Spec.Type)).Now()
+		}
+		nodePort, found := serviceNodePort(svc, port)
+		if !found {
+			gomega.StopTrying(fmt.Sprintf("gateway service %s/%s does not expose port %d: %+v", serviceNamespace, serviceName, port, svc.Spec.Ports)).Now()
+		}
+		g.Expect(nodePort).NotTo(gomega.BeZero(), "node port is not allocated yet")
+
+		nodes := &corev1.NodeList{}
+		g.Expect(p.clusterContext.Client.List(ctx, nodes)).To(gomega.Succeed(), "can list nodes")
+		nodeIP, found := readyNodeAddress(nodes.Items)
+		g.Expect(found).To(gomega.BeTrue(), "no Ready node with an ExternalIP or InternalIP address")
+
+		addr = net.JoinHostPort(nodeIP, strconv.Itoa(int(nodePort)))
+	}, gatewayAddressTimeout, helpers.DefaultPollingInterval).Should(gomega.Succeed())
 	return addr
 }
 
+// serviceNodePort returns the node port allocated for the given service port
+func serviceNodePort(svc *corev1.Service, port int32) (int32, bool) {
+	for _, p := range svc.Spec.Ports {
+		if p.Port == port {
+			return p.NodePort, true
+		}
+	}
+	return 0, false
+}
+
+// readyNodeAddress returns the ExternalIP, or else the InternalIP, of the first Ready node which has one
+func readyNodeAddress(nodes []corev1.Node) (string, bool) {
+	for _, addrType := range []corev1.NodeAddressType{corev1.NodeExternalIP, corev1.NodeInternalIP} {
+		for _, node := range nodes {
+			if !isNodeReady(node) {
+				continue
+			}
+			for _, a := range node.Status.Addresses {
+				if a.Type == addrType && a.Address != "" {
+					return a.Address, true
+				}
+			}
+		}
+	}
+	return "", false
+}
+
+func isNodeReady(node corev1.Node) bool {
+	for _, c := range node.Status.Conditions {
+		if c.Type == corev1.NodeReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
 // EventuallyHTTPRouteStatusContainsMessage asserts that eventually at least one of the HTTPRoute's route parent statuses contains
 // the given message substring.
 func (p *Provider) EventuallyHTTPRouteStatusContainsMessage(
@@ -547,6 +671,21 @@ func (p *Provider) EventuallyAgwPolicyCondition(
 	condType string,
 	expect metav1.ConditionStatus,
 	timeout ...time.Duration,
+) {
+	ginkgo.GinkgoHelper()
+	p.EventuallyAgwPolicyConditionWithReason(ctx, name, namespace, condType, expect, "", timeout...)
+}
+
+// EventuallyAgwPolicyConditionWithReason checks that provided AgentgatewayPolicy condition is set to expect
+// on some ancestor, with a reason containing the given reason.
+func (p *Provider) EventuallyAgwPolicyConditionWithReason(
+	ctx context.Context,
+	name string,
+	namespace string,
+	condType string,
+	expect metav1.ConditionStatus,
+	reason string,
+	timeout ...time.Duration,
 ) {
 	ginkgo.GinkgoHelper()
 	currentTimeout, pollingInterval := helpers.GetTimeouts(timeout...)
@@ -558,12 +697,12 @@ func (p *Provider) EventuallyAgwPolicyCondition(
 		var conditionFound bool
 		for _, parentStatus := range policy.Status.Ancestors {
 			condition := GetConditionByType(parentStatus.Conditions, condType)
-			if condition != nil && condition.Status == expect {
+			if condition != nil && condition.Status == expect && strings.Contains(condition.Reason, reason) {
 				conditionFound = true
 				break
 			}
 		}
-		g.Expect(conditionFound).To(gomega.BeTrue(), fmt.Sprintf("%v condition is not %v for any ancestor of AgentgatewayPolicy %s/%s. Full status: %+v",
-			condType, expect, namespace, name, policy.Status))
+		g.Expect(conditionFound).To(gomega.BeTrue(), fmt.Sprintf("%v condition is not %v with reason %q for any ancestor of AgentgatewayPolicy %s/%s. Full status: %+v",
+			condType, expect, reason, namespace, name, policy.Status))
 	}, currentTimeout, pollingInterval).Should(gomega.Succeed())
 }
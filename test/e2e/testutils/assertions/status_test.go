@@ -0,0 +1,269 @@
+//go:build e2e
+
+package assertions
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/agentgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/schemes"
+	"github.com/kgateway-dev/kgateway/v2/test/e2e/testutils/cluster"
+)
+
+func TestEventuallyGatewayAddress(t *testing.T) {
+	addresses := []gwv1.GatewayStatusAddress{
+		{Type: ptr.To(gwv1.HostnameAddressType), Value: "gw.example.com"},
+		{Type: ptr.To(gwv1.IPAddressType), Value: "10.0.0.1"},
+		{Value: "10.0.0.2"},
+	}
+
+	testCases := []struct {
+		name      string
+		addresses []gwv1.GatewayStatusAddress
+		prefer    []gwv1.AddressType
+		expected  string
+	}{
+		{
+			name:      "defaults to the first IP address",
+			addresses: addresses,
+			expected:  "10.0.0.1",
+		},
+		{
+			name:      "unset type is an IP address",
+			addresses: addresses[2:],
+			expected:  "10.0.0.2",
+		},
+		{
+			name:      "falls back to any address without a preference",
+			addresses: addresses[:1],
+			expected:  "gw.example.com",
+		},
+		{
+			name:      "prefers hostname",
+			addresses: addresses,
+			prefer:    []gwv1.AddressType{gwv1.HostnameAddressType},
+			expected:  "gw.example.com",
+		},
+		{
+			name:      "preferences are checked in order",
+			addresses: addresses[1:],
+			prefer:    []gwv1.AddressType{gwv1.HostnameAddressType, gwv1.IPAddressType},
+			expected:  "10.0.0.1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, _ := newGatewayStatusProvider(t, tc.addresses)
+
+			if got := p.EventuallyGatewayAddress(t.Context(), "gw", "default", tc.prefer...); got != tc.expected {
+				t.Fatalf("expected address %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestEventuallyGatewayAddressWaitsForAddresses(t *testing.T) {
+	p, gw := newGatewayStatusProvider(t, nil)
+
+	returned := make(chan string, 1)
+	go func() {
+		returned <- p.EventuallyGatewayAddress(t.Context(), "gw", "default")
+	}()
+
+	select {
+	case addr := <-returned:
+		t.Fatalf("expected to block until the gateway has an address, got %q", addr)
+	case <-time.After(500 * time.Millisecond):
+	}
+
+	gw.Status.Addresses = []gwv1.GatewayStatusAddress{{Type: ptr.To(gwv1.IPAddressType), Value: "10.0.0.1"}}
+	if err := p.clusterContext.Client.Status().Update(t.Context(), gw); err != nil {
+		t.Fatalf("failed to update gateway status: %v", err)
+	}
+
+	select {
+	case addr := <-returned:
+		if addr != "10.0.0.1" {
+			t.Fatalf("expected address %q, got %q", "10.0.0.1", addr)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("expected to return once the gateway has an address")
+	}
+}
+
+func TestEventuallyGatewayNodePortAddress(t *testing.T) {
+	node := func(name string, ready corev1.ConditionStatus, addresses ...corev1.NodeAddress) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: ready}},
+				Addresses:  addresses,
+			},
+		}
+	}
+	service := func(svcType corev1.ServiceType) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "default"},
+			Spec: corev1.ServiceSpec{
+				Type:  svcType,
+				Ports: []corev1.ServicePort{{Port: 80, NodePort: 30080}, {Port: 443, NodePort: 30443}},
+			},
+		}
+	}
+	internalIP := func(ip string) corev1.NodeAddress {
+		return corev1.NodeAddress{Type: corev1.NodeInternalIP, Address: ip}
+	}
+	externalIP := func(ip string) corev1.NodeAddress {
+		return corev1.NodeAddress{Type: corev1.NodeExternalIP, Address: ip}
+	}
+
+	testCases := []struct {
+		name            string
+		objects         []client.Object
+		port            int32
+		expected        string
+		expectedFailure string
+	}{
+		{
+			name:     "internal IP of a ready node",
+			objects:  []client.Object{service(corev1.ServiceTypeNodePort), node("kind", corev1.ConditionTrue, internalIP("172.18.0.2"))},
+			port:     80,
+			expected: "172.18.0.2:30080",
+		},
+		{
+			name: "prefers an external IP",
+			objects: []client.Object{
+				service(corev1.ServiceTypeNodePort),
+				node("a", corev1.ConditionTrue, internalIP("10.0.0.1")),
+				node("b", corev1.ConditionTrue, internalIP("10.0.0.2"), externalIP("203.0.113.2")),
+			},
+			port:     443,
+			expected: "203.0.113.2:30443",
+		},
+		{
+			name: "skips nodes that are not ready",
+			objects: []client.Object{
+				service(corev1.ServiceTypeNodePort),
+				node("a", corev1.ConditionFalse, externalIP("203.0.113.1")),
+				node("b", corev1.ConditionTrue, internalIP("10.0.0.2")),
+			},
+			port:     80,
+			expected: "10.0.0.2:30080",
+		},
+		{
+			name:            "service is not NodePort",
+			objects:         []client.Object{service(corev1.ServiceTypeLoadBalancer), node("kind", corev1.ConditionTrue, internalIP("172.18.0.2"))},
+			port:            80,
+			expectedFailure: "is of type LoadBalancer, not NodePort",
+		},
+		{
+			name:            "port is not exposed",
+			objects:         []client.Object{service(corev1.ServiceTypeNodePort), node("kind", corev1.ConditionTrue, internalIP("172.18.0.2"))},
+			port:            8080,
+			expectedFailure: "does not expose port 8080",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cli := fake.NewClientBuilder().WithScheme(schemes.DefaultScheme()).WithObjects(tc.objects...).Build()
+			p := NewProvider(t).WithClusterContext(&cluster.Context{Client: cli})
+			var failure string
+			p.Gomega = gomega.NewGomega(func(message string, _ ...int) {
+				failure = message
+			})
+
+			got := p.EventuallyGatewayNodePortAddress(t.Context(), "gw", "default", tc.port)
+
+			if tc.expectedFailure != "" {
+				if !strings.Contains(failure, tc.expectedFailure) {
+					t.Fatalf("expected failure containing %q, got: %q", tc.expectedFailure, failure)
+				}
+				return
+			}
+			if failure != "" {
+				t.Fatalf("expected assertion to succeed, got: %s", failure)
+			}
+			if got != tc.expected {
+				t.Fatalf("expected address %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestEventuallyAllGatewayAddresses(t *testing.T) {
+	addresses := []gwv1.GatewayStatusAddress{
+		{Type: ptr.To(gwv1.HostnameAddressType), Value: "gw.example.com"},
+		{Type: ptr.To(gwv1.IPAddressType), Value: "10.0.0.1"},
+	}
+	p, _ := newGatewayStatusProvider(t, addresses)
+
+	got := p.EventuallyAllGatewayAddresses(t.Context(), "gw", "default")
+	p.Gomega.Expect(got).To(gomega.Equal(addresses))
+}
+
+func TestEventuallyAgwPolicyConditionWithReason(t *testing.T) {
+	policy := &agentgateway.AgentgatewayPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "agw", Namespace: "default"},
+		Status: gwv1.PolicyStatus{
+			Ancestors: []gwv1.PolicyAncestorStatus{{
+				Conditions: []metav1.Condition{
+					{Type: "Accepted", Status: metav1.ConditionTrue, Reason: "Invalid"},
+					{Type: "Attached", Status: metav1.ConditionFalse, Reason: "Pending"},
+				},
+			}},
+		},
+	}
+	cli := fake.NewClientBuilder().
+		WithScheme(schemes.GatewayScheme()).
+		WithObjects(policy).
+		WithStatusSubresource(policy).
+		Build()
+
+	t.Run("matches status and reason", func(t *testing.T) {
+		p := NewProvider(t).WithClusterContext(&cluster.Context{Client: cli})
+		p.EventuallyAgwPolicyConditionWithReason(t.Context(), "agw", "default", "Accepted", metav1.ConditionTrue, "Invalid", time.Second)
+		p.EventuallyAgwPolicyConditionWithReason(t.Context(), "agw", "default", "Attached", metav1.ConditionFalse, "Pend", time.Second)
+	})
+
+	t.Run("times out with the expected reason in the failure", func(t *testing.T) {
+		p := NewProvider(t).WithClusterContext(&cluster.Context{Client: cli})
+		var failures []string
+		p.Gomega = gomega.NewGomega(func(message string, _ ...int) {
+			failures = append(failures, message)
+		})
+
+		p.EventuallyAgwPolicyConditionWithReason(t.Context(), "agw", "default", "Accepted", metav1.ConditionTrue, "Valid", 500*time.Millisecond, 100*time.Millisecond)
+		p.Gomega.Expect(failures).To(gomega.HaveLen(1))
+		p.Gomega.Expect(failures[0]).To(gomega.ContainSubstring(`Accepted condition is not True with reason "Valid"`))
+	})
+}
+
+// newGatewayStatusProvider returns a Provider backed by a fake client containing a single Gateway
+// default/gw with the given status addresses
+func newGatewayStatusProvider(t *testing.T, addresses []gwv1.GatewayStatusAddress) (*Provider, *gwv1.Gateway) {
+	t.Helper()
+
+	gw := &gwv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "default"},
+		Status:     gwv1.GatewayStatus{Addresses: addresses},
+	}
+	cli := fake.NewClientBuilder().
+		WithScheme(schemes.GatewayScheme()).
+		WithObjects(gw).
+		WithStatusSubresource(gw).
+		Build()
+
+	return NewProvider(t).WithClusterContext(&cluster.Context{Client: cli}), gw
+}
@@ -0,0 +1,140 @@
+package portforward
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/avast/retry-go/v4"
+
+	kubeportforward "github.com/kgateway-dev/kgateway/v2/pkg/utils/kubeutils/portforward"
+	"github.com/kgateway-dev/kgateway/v2/pkg/utils/requestutils/curl"
+)
+
+const (
+	defaultReadyTimeout = 30 * time.Second
+	pollInterval        = 250 * time.Millisecond
+)
+
+type config struct {
+	readyTimeout time.Duration
+}
+
+// Option configures StartPortForward
+type Option func(*config)
+
+// WithReadyTimeout sets how long StartPortForward waits for the local port to accept connections
+func WithReadyTimeout(timeout time.Duration) Option {
+	return func(c *config) {
+		c.readyTimeout = timeout
+	}
+}
+
+// StartPortForward spawns `kubectl port-forward` to the given resource, which may be a raw pod name
+// or a kubectl-style selector like `deployment/<name>` or `service/<name>`.
+// It blocks until the local port is accepting connections, and returns the local address in host:port form.
+// The port-forward process is killed once ctx is cancelled.
+func StartPortForward(
+	ctx context.Context,
+	namespace, resource string,
+	localPort, remotePort int,
+	options ...Option,
+) (string, error) {
+	cfg := &config{readyTimeout: defaultReadyTimeout}
+	for _, opt := range options {
+		opt(cfg)
+	}
+
+	pf := kubeportforward.NewCliPortForwarder(
+		kubeportforward.WithResourceSelector(resource, namespace),
+		kubeportforward.WithPorts(localPort, remotePort),
+	)
+
+	readyCtx, readyCancel := context.WithTimeout(ctx, cfg.readyTimeout)
+	defer readyCancel()
+
+	err := retry.Do(func() error {
+		err := pf.Start(ctx, retry.Attempts(1))
+		if err == nil {
+			err = waitForPort(readyCtx, pf.Address())
+		}
+		if err != nil {
+			// reap the subprocess before the next attempt spawns a new one
+			pf.Close()
+			pf.WaitForStop()
+		}
+		return err
+	},
+		retry.Context(readyCtx),
+		retry.LastErrorOnly(true),
+		retry.Delay(pollInterval),
+		retry.DelayType(retry.FixedDelay),
+		retry.Attempts(0),
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to port-forward %s/%s: %w", namespace, resource, err)
+	}
+
+	go func() {
+		<-ctx.Done()
+		pf.Close()
+		pf.WaitForStop()
+	}()
+
+	return pf.Address(), nil
+}
+
+// StartServicePortForward port-forwards a free local port to the given port of a Service, such as the one of a Gateway,
+// and returns the curl options which send requests through it, along with a func which stops the port-forward.
+// This lets the native assertions run from outside the cluster, where Service FQDNs do not resolve, by passing
+// these options in place of curl.WithHost and curl.WithPort:
+//
+//	curlOpts, stop, err := portforward.StartServicePortForward(ctx, "default", "gw", 8080)
+//	defer stop()
+//	assertions.AssertEventualCurlResponseNative(ctx, append(curlOpts, curl.WithHostHeader("example.com")), expected)
+func StartServicePortForward(
+	ctx context.Context,
+	namespace, serviceName string,
+	servicePort int,
+	options ...Option,
+) ([]curl.Option, func(), error) {
+	localPort, err := freeLocalPort()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	address, err := StartPortForward(ctx, namespace, "service/"+serviceName, localPort, servicePort, options...)
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+	return []curl.Option{curl.WithHostPort(address)}, cancel, nil
+}
+
+// freeLocalPort returns a local port which is not currently in use
+func freeLocalPort() (int, error) {
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		return 0, fmt.Errorf("failed to find a free local port: %w", err)
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
+
+// waitForPort polls address until it accepts a TCP connection or ctx is done
+func waitForPort(ctx context.Context, address string) error {
+	var dialer net.Dialer
+	for {
+		conn, err := dialer.DialContext(ctx, "tcp", address)
+		if err == nil {
+			return conn.Close()
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%s is not accepting connections: %w", address, err)
+		case <-time.After(pollInterval):
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package portforward
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/utils/requestutils/curl"
+)
+
+const (
+	helperProcessEnv = "GO_WANT_HELPER_PROCESS"
+	helperFailEnv    = "FAKE_KUBECTL_FAIL"
+)
+
+// TestHelperProcess is not a real test. It is executed by the fake kubectl installed by installFakeKubectl,
+// and mimics `kubectl port-forward` by listening on the requested local port until it is killed.
+func TestHelperProcess(t *testing.T) {
+	if os.Getenv(helperProcessEnv) != "1" {
+		t.Skip("only runs as a helper process")
+	}
+
+	if os.Getenv(helperFailEnv) == "1" {
+		fmt.Fprintln(os.Stderr, "error: pods \"missing\" not found")
+		os.Exit(1)
+	}
+
+	// the ports argument is the last one: `port-forward -n <ns> <resource> <local>:<remote>`
+	ports := os.Args[len(os.Args)-1]
+	localPort, _, _ := strings.Cut(ports, ":")
+	l, err := net.Listen("tcp", net.JoinHostPort("localhost", localPort))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fmt.Printf("Forwarding from %s -> %s\n", l.Addr(), ports)
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			os.Exit(1)
+		}
+		conn.Close()
+	}
+}
+
+// installFakeKubectl puts a kubectl on the PATH which re-executes the test binary as TestHelperProcess
+func installFakeKubectl(t *testing.T, fail bool) {
+	t.Helper()
+
+	dir := t.TempDir()
+	script := fmt.Sprintf("#!/bin/sh\nexec %q -test.run='^TestHelperProcess$' -- \"$@\"\n", os.Args[0])
+	if err := os.WriteFile(filepath.Join(dir, "kubectl"), []byte(script), 0o755); err != nil { //nolint:gosec // G306: the script must be executable
+		t.Fatalf("failed to write fake kubectl: %v", err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv(helperProcessEnv, "1")
+	if fail {
+		t.Setenv(helperFailEnv, "1")
+	}
+}
+
+func freePort(t *testing.T) int {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("failed to find a free port: %v", err)
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port
+}
+
+func TestStartPortForward(t *testing.T) {
+	installFakeKubectl(t, false)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	localPort := freePort(t)
+	address, err := StartPortForward(ctx, "default", "deployment/gateway", localPort, 8080, WithReadyTimeout(10*time.Second))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := net.JoinHostPort("localhost", fmt.Sprint(localPort)); address != want {
+		t.Fatalf("expected address %q, got %q", want, address)
+	}
+
+	conn, err := net.Dial("tcp", address)
+	if err != nil {
+		t.Fatalf("expected port-forward to accept connections: %v", err)
+	}
+	conn.Close()
+
+	cancel()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		conn, err := net.DialTimeout("tcp", address, 100*time.Millisecond)
+		if err != nil {
+			break
+		}
+		conn.Close()
+		if time.Now().After(deadline) {
+			t.Fatal("expected port to become unavailable within 2s of cancelling the context")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func TestStartPortForwardError(t *testing.T) {
+	installFakeKubectl(t, true)
+
+	start := time.Now()
+	_, err := StartPortForward(t.Context(), "default", "missing", freePort(t), 8080, WithReadyTimeout(time.Second))
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if !strings.Contains(err.Error(), "failed to port-forward default/missing") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("expected the ready timeout to bound retries, took %s", elapsed)
+	}
+}
+
+func TestStartServicePortForward(t *testing.T) {
+	installFakeKubectl(t, false)
+
+	curlOpts, stop, err := StartServicePortForward(t.Context(), "default", "gw", 8080, WithReadyTimeout(10*time.Second))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer stop()
+
+	// the URL is the last curl argument
+	args := curl.BuildArgs(curlOpts...)
+	url := args[len(args)-1]
+	address := strings.TrimPrefix(url, "http://")
+	address, _, _ = strings.Cut(address, "/")
+	if !strings.HasPrefix(address, "localhost:") {
+		t.Fatalf("expected curl options to target localhost, got %q", url)
+	}
+
+	conn, err := net.Dial("tcp", address)
+	if err != nil {
+		t.Fatalf("expected port-forward to accept connections: %v", err)
+	}
+	conn.Close()
+
+	stop()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		conn, err := net.DialTimeout("tcp", address, 100*time.Millisecond)
+		if err != nil {
+			break
+		}
+		conn.Close()
+		if time.Now().After(deadline) {
+			t.Fatal("expected port to become unavailable within 2s of stopping the port-forward")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
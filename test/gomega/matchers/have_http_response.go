@@ -1,10 +1,13 @@
 package matchers
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"strings"
 
 	"github.com/onsi/gomega"
 	"github.com/onsi/gomega/gstruct"
@@ -78,10 +81,23 @@ type HttpResponse struct {
 	// Body can be of type: {string, bytes, GomegaMatcher}
 	// Optional: If not provided, defaults to an empty string
 	Body any
+	// JSONBody is the expected JSON response body for an http.Response, compared semantically so that
+	// key ordering and whitespace do not matter. If set, it takes precedence over Body.
+	// JSONBody can be of type:
+	//   - {string, bytes}: the JSON document the body must be equivalent to
+	//   - GomegaMatcher: applied to the body after it is unmarshalled into an `any` (e.g. a map[string]any)
+	//   - any other value: marshalled to JSON, which the body must be equivalent to
+	// Optional: If not provided, the body is matched using Body
+	JSONBody any
 	// Headers is the set of expected header values for an http.Response
 	// Each header can be of type: {string, GomegaMatcher}
 	// Optional: If not provided, does not perform header validation
 	Headers map[string]any
+	// Trailers is the set of expected trailer values for an http.Response, such as grpc-status
+	// Each trailer can be of type: {string, GomegaMatcher}
+	// Since trailers are only populated once the body has been fully read, the body is drained before they are matched
+	// Optional: If not provided, does not perform trailer validation
+	Trailers map[string]any
 	// NotHeaders is a list of headers that should not be present in the response
 	// Optional: If not provided, does not perform header absence validation
 	NotHeaders []string
@@ -102,9 +118,12 @@ func (r *HttpResponse) String() string {
 	case types.GomegaMatcher:
 		bodyString = fmt.Sprintf("%#v", bodyMatcher)
 	}
+	if r.JSONBody != nil {
+		bodyString = fmt.Sprintf("JSON(%v)", r.JSONBody)
+	}
 
-	return fmt.Sprintf("HttpResponse{StatusCode: %d, Body: %s, Headers: %v, NotHeaders: %v, Custom: %v}",
-		r.StatusCode, bodyString, r.Headers, r.NotHeaders, r.Custom)
+	return fmt.Sprintf("HttpResponse{StatusCode: %d, Body: %s, Headers: %v, Trailers: %v, NotHeaders: %v, Custom: %v}",
+		r.StatusCode, bodyString, r.Headers, r.Trailers, r.NotHeaders, r.Custom)
 }
 
 // HaveHttpResponse returns a GomegaMatcher which validates that an http.Response contains
@@ -123,7 +142,18 @@ func HaveHttpResponse(expected *HttpResponse) types.GomegaMatcher {
 			expected.StatusCode,
 		},
 	})
-	if expected.Body != nil {
+	// trailers are matched before the body, as the body matcher closes the body once it has been read
+	for trailerName, trailerMatch := range expected.Trailers {
+		partialResponseMatchers = append(partialResponseMatchers, &HaveHTTPTrailerWithValueMatcher{
+			Trailer: trailerName,
+			Value:   trailerMatch,
+		})
+	}
+	if expected.JSONBody != nil {
+		partialResponseMatchers = append(partialResponseMatchers, &matchers.HaveHTTPBodyMatcher{
+			Expected: jsonBodyMatcher(expected.JSONBody),
+		})
+	} else if expected.Body != nil {
 		partialResponseMatchers = append(partialResponseMatchers, &matchers.HaveHTTPBodyMatcher{
 			Expected: expected.Body,
 		})
@@ -147,6 +177,31 @@ func HaveHttpResponse(expected *HttpResponse) types.GomegaMatcher {
 	}
 }
 
+// jsonBodyMatcher returns a matcher for a raw response body, which compares it to the expected JSON body
+func jsonBodyMatcher(expected any) types.GomegaMatcher {
+	switch expectedBody := expected.(type) {
+	case string, []byte:
+		return gomega.MatchJSON(expectedBody)
+	case types.GomegaMatcher:
+		return gomega.WithTransform(func(body []byte) (any, error) {
+			var actual any
+			if err := json.Unmarshal(body, &actual); err != nil {
+				return nil, fmt.Errorf("response body is not valid JSON: %w", err)
+			}
+			return actual, nil
+		}, expectedBody)
+	default:
+		expectedJSON, err := json.Marshal(expectedBody)
+		if err != nil {
+			// surface the error when the matcher is evaluated, rather than panicking while building it
+			return gomega.WithTransform(func([]byte) (any, error) {
+				return nil, fmt.Errorf("failed to marshal expected JSON body: %w", err)
+			}, gstruct.Ignore())
+		}
+		return gomega.MatchJSON(expectedJSON)
+	}
+}
+
 type HaveHttpResponseMatcher struct {
 	Expected *HttpResponse
 
@@ -224,6 +279,69 @@ func (m *NotHaveHTTPHeaderMatcher) NegatedFailureMessage(actual any) string {
 	return fmt.Sprintf("Expected HTTP response to have header '%s', but it was not present", m.Header)
 }
 
+// HaveHTTPTrailerWithValueMatcher is a matcher that checks the value of a trailer in the HTTP response
+// The value can be a string, which must match exactly, or a GomegaMatcher.
+// Trailers are only populated once the body has been fully read, so the body is drained before the trailer is checked.
+// The drained body is buffered and restored, so that it can still be read by other matchers.
+type HaveHTTPTrailerWithValueMatcher struct {
+	Trailer string
+	Value   any
+
+	valueMatcher types.GomegaMatcher
+	found        bool
+	actualValue  string
+}
+
+func (m *HaveHTTPTrailerWithValueMatcher) Match(actual any) (success bool, err error) {
+	response, ok := actual.(*http.Response)
+	if !ok {
+		return false, fmt.Errorf("HaveHTTPTrailerWithValueMatcher expects an *http.Response, got %T", actual)
+	}
+	if response == nil {
+		return false, errors.New("HaveHTTPTrailerWithValueMatcher matcher requires a non-nil *http.Response")
+	}
+
+	if response.Body != nil {
+		body, err := io.ReadAll(response.Body)
+		if err != nil {
+			return false, fmt.Errorf("failed to read response body: %w", err)
+		}
+		response.Body.Close()
+		response.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	switch value := m.Value.(type) {
+	case string:
+		m.valueMatcher = gomega.Equal(value)
+	case types.GomegaMatcher:
+		m.valueMatcher = value
+	default:
+		return false, fmt.Errorf("HaveHTTPTrailerWithValueMatcher expects a string or GomegaMatcher value, got %T", m.Value)
+	}
+
+	values, found := response.Trailer[http.CanonicalHeaderKey(m.Trailer)]
+	if !found {
+		return false, nil
+	}
+	m.found = true
+	m.actualValue = strings.Join(values, ", ")
+	return m.valueMatcher.Match(m.actualValue)
+}
+
+func (m *HaveHTTPTrailerWithValueMatcher) FailureMessage(actual any) string {
+	if !m.found {
+		return fmt.Sprintf("Expected HTTP response to have trailer '%s', but it was not present", m.Trailer)
+	}
+	return fmt.Sprintf("HTTP trailer '%s':\n%s", m.Trailer, m.valueMatcher.FailureMessage(m.actualValue))
+}
+
+func (m *HaveHTTPTrailerWithValueMatcher) NegatedFailureMessage(actual any) string {
+	if !m.found {
+		return fmt.Sprintf("Expected HTTP response not to have trailer '%s'", m.Trailer)
+	}
+	return fmt.Sprintf("HTTP trailer '%s':\n%s", m.Trailer, m.valueMatcher.NegatedFailureMessage(m.actualValue))
+}
+
 // informativeComparison returns a string which presents data to the user to help them understand why a failure occurred.
 // The HaveHttpResponseMatcher uses an And matcher, which intentionally short-circuits and only
 // logs the first failure that occurred.
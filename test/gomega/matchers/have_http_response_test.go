@@ -0,0 +1,186 @@
+package matchers_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gstruct"
+
+	"github.com/kgateway-dev/kgateway/v2/test/gomega/matchers"
+)
+
+var _ = Describe("HaveHttpResponse", func() {
+
+	// newResponse returns a 200 response with the provided body
+	newResponse := func(body string) *http.Response {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}
+	}
+
+	Context("JSONBody", func() {
+
+		const body = `{"b": [1, 2], "a": {"c": "d"}}`
+
+		DescribeTable("matches the body as JSON",
+			func(jsonBody any, result bool) {
+				matcher := matchers.HaveHttpResponse(&matchers.HttpResponse{
+					StatusCode: http.StatusOK,
+					JSONBody:   jsonBody,
+				})
+				if result {
+					Expect(newResponse(body)).To(matcher)
+				} else {
+					Expect(newResponse(body)).NotTo(matcher)
+				}
+			},
+			Entry("equivalent string ignoring order and whitespace", `{"a":{"c":"d"},"b":[1,2]}`, true),
+			Entry("different string", `{"a":{"c":"e"},"b":[1,2]}`, false),
+			Entry("equivalent bytes", []byte(`{"a":{"c":"d"},"b":[1,2]}`), true),
+			Entry("equivalent map", map[string]any{"a": map[string]string{"c": "d"}, "b": []int{1, 2}}, true),
+			Entry("map with missing key", map[string]any{"a": map[string]string{"c": "d"}}, false),
+			Entry("equivalent struct", struct {
+				A map[string]string `json:"a"`
+				B []int             `json:"b"`
+			}{A: map[string]string{"c": "d"}, B: []int{1, 2}}, true),
+			Entry("matcher on unmarshalled body", HaveKeyWithValue("a", HaveKeyWithValue("c", "d")), true),
+			Entry("failing matcher on unmarshalled body", HaveKey("missing"), false),
+		)
+
+		It("returns an error for a body which is not JSON", func() {
+			success, err := matchers.HaveHttpResponse(&matchers.HttpResponse{
+				StatusCode: http.StatusOK,
+				JSONBody:   gstruct.Ignore(),
+			}).Match(newResponse("not json"))
+			Expect(err).To(MatchError(ContainSubstring("response body is not valid JSON")))
+			Expect(success).To(BeFalse())
+		})
+
+		It("takes precedence over Body", func() {
+			Expect(newResponse(body)).To(matchers.HaveHttpResponse(&matchers.HttpResponse{
+				StatusCode: http.StatusOK,
+				Body:       "does not match",
+				JSONBody:   `{"a":{"c":"d"},"b":[1,2]}`,
+			}))
+		})
+
+		It("reports a diff on mismatch", func() {
+			matcher := matchers.HaveHttpResponse(&matchers.HttpResponse{
+				StatusCode: http.StatusOK,
+				JSONBody:   `{"a":{"c":"e"},"b":[1,2]}`,
+			})
+			resp := newResponse(body)
+			Expect(matcher.Match(resp)).To(BeFalse())
+			Expect(matcher.FailureMessage(resp)).To(ContainSubstring(`first mismatched key: "a"`))
+		})
+	})
+
+	Context("Headers", func() {
+
+		newResponseWithHeaders := func() *http.Response {
+			resp := newResponse("")
+			resp.Header = http.Header{
+				"X-Request-Id": []string{"7f8d2a4e-1c3b-4f5a-9e6d-0b1a2c3d4e5f"},
+				"Content-Type": []string{"application/json"},
+			}
+			return resp
+		}
+
+		DescribeTable("matches header values",
+			func(value any, result bool) {
+				matcher := matchers.HaveHttpResponse(&matchers.HttpResponse{
+					StatusCode: http.StatusOK,
+					Headers:    map[string]any{"x-request-id": value},
+				})
+				if result {
+					Expect(newResponseWithHeaders()).To(matcher)
+				} else {
+					Expect(newResponseWithHeaders()).NotTo(matcher)
+				}
+			},
+			Entry("exact string", "7f8d2a4e-1c3b-4f5a-9e6d-0b1a2c3d4e5f", true),
+			Entry("different string", "7f8d2a4e", false),
+			Entry("matching regexp", MatchRegexp(`^[0-9a-f]{8}(-[0-9a-f]{4}){3}-[0-9a-f]{12}$`), true),
+			Entry("non-matching regexp", MatchRegexp(`^[0-9]+$`), false),
+			Entry("matching substring", ContainSubstring("1c3b"), true),
+			Entry("non-matching substring", ContainSubstring("ffff"), false),
+		)
+
+		It("matches a mix of string and matcher header values", func() {
+			Expect(newResponseWithHeaders()).To(matchers.HaveHttpResponse(&matchers.HttpResponse{
+				StatusCode: http.StatusOK,
+				Headers: map[string]any{
+					"content-type": "application/json",
+					"x-request-id": MatchRegexp(`^[0-9a-f-]+$`),
+				},
+			}))
+		})
+	})
+
+	Context("Trailers", func() {
+
+		var server *httptest.Server
+
+		BeforeEach(func() {
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Trailer", "Grpc-Status, Grpc-Message")
+				w.Write([]byte("hello"))
+				w.Header().Set("Grpc-Status", "0")
+				w.Header().Set("Grpc-Message", "request succeeded")
+			}))
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		get := func() *http.Response {
+			resp, err := http.Get(server.URL)
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(resp.Body.Close)
+			return resp
+		}
+
+		DescribeTable("matches trailer values once the body is drained",
+			func(trailers map[string]any, result bool) {
+				matcher := matchers.HaveHttpResponse(&matchers.HttpResponse{
+					StatusCode: http.StatusOK,
+					Trailers:   trailers,
+				})
+				if result {
+					Expect(get()).To(matcher)
+				} else {
+					Expect(get()).NotTo(matcher)
+				}
+			},
+			Entry("exact string", map[string]any{"grpc-status": "0"}, true),
+			Entry("different string", map[string]any{"grpc-status": "14"}, false),
+			Entry("matcher", map[string]any{"grpc-message": ContainSubstring("succeeded")}, true),
+			Entry("missing trailer", map[string]any{"missing": "0"}, false),
+		)
+
+		It("leaves the body readable after matching trailers", func() {
+			resp := get()
+			Expect(resp).To(matchers.HaveHttpResponse(&matchers.HttpResponse{
+				StatusCode: http.StatusOK,
+				Trailers:   map[string]any{"grpc-status": "0"},
+			}))
+			body, err := io.ReadAll(resp.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(body)).To(Equal("hello"))
+		})
+
+		It("matches trailers together with the body", func() {
+			Expect(get()).To(matchers.HaveHttpResponse(&matchers.HttpResponse{
+				StatusCode: http.StatusOK,
+				Body:       "hello",
+				Trailers:   map[string]any{"grpc-status": "0"},
+			}))
+		})
+	})
+})
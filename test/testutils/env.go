@@ -74,6 +74,10 @@ const (
 	// The default KubeCtx used is "kind-<ClusterName>"
 	KubeCtx = "KUBE_CTX"
 
+	// NetworkPolicyEnforced indicates that the CNI of the cluster enforces NetworkPolicies, which the default kind CNI
+	// does not. Tests which rely on NetworkPolicies being enforced are skipped unless it is set.
+	NetworkPolicyEnforced = "NETWORK_POLICY_ENFORCED"
+
 	// DefaultNamespace is the default namespace to use for resources that don't specify one
 	// Typically "default" for kind/k8s clusters, may differ for OpenShift/CRC
 	DefaultNamespace = "DEFAULT_NAMESPACE"
@@ -115,6 +119,11 @@ func ShouldSkipBugReport() bool {
 	return envutils.IsEnvTruthy(SkipBugReport)
 }
 
+// ShouldEnforceNetworkPolicies returns true if the cluster enforces NetworkPolicies.
+func ShouldEnforceNetworkPolicies() bool {
+	return envutils.IsEnvTruthy(NetworkPolicyEnforced)
+}
+
 // TestingT is an interface that matches the subset of testing.T methods we need
 type TestingT interface {
 	Failed() bool